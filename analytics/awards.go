@@ -0,0 +1,94 @@
+// This file is responsible for the aggregation of the awards (medals) over many matches.
+
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// topAwards is the number of awards in the most frequent and the rarest lists.
+const topAwards = 5
+
+// MatchAward is a single award from a match.
+type MatchAward struct {
+	Award    string        `json:"award"`
+	Name     string        `json:"name"`
+	EarnedAt time.Duration `json:"earnedAt"`
+}
+
+// AwardCount is the number of matches that the award was earned in.
+type AwardCount struct {
+	Award   string  `json:"award"`
+	Name    string  `json:"name"`
+	Matches int     `json:"matches"`
+	Percent float64 `json:"percent"` // percent of the matches
+}
+
+// AwardsSummary is the aggregation of the awards over matches, Awards holds all the awards sorted from the most frequent.
+type AwardsSummary struct {
+	Matches      int          `json:"matches"`
+	Total        int          `json:"total"`
+	Awards       []AwardCount `json:"awards"`
+	MostFrequent []AwardCount `json:"mostFrequent"`
+	Rarest       []AwardCount `json:"rarest"` // sorted from the rarest award, without the awards of MostFrequent
+}
+
+// PlayerAwards is the awards summary of a single player.
+type PlayerAwards struct {
+	Player string        `json:"player"` // platform:username
+	Awards AwardsSummary `json:"awards"`
+}
+
+// MatchAwards return the awards of a single match sorted by the time they were earned.
+func MatchAwards(awards activision.Awards) []MatchAward {
+	result := make([]MatchAward, 0, len(awards))
+	for _, key := range awards.Keys() {
+		result = append(result, MatchAward{Award: key, Name: activision.AwardName(key), EarnedAt: awards.EarnedAt(key)})
+	}
+	return result
+}
+
+// MatchesAwards aggregate the awards of the player matches.
+func MatchesAwards(matches []activision.Match) AwardsSummary {
+	awards := make([]activision.Awards, 0, len(matches))
+	for _, m := range matches {
+		awards = append(awards, m.Player.Awards)
+	}
+	return AggregateAwards(awards)
+}
+
+// AggregateAwards aggregate the awards of many matches, every item is the awards of one match.
+func AggregateAwards(awards []activision.Awards) AwardsSummary {
+	summary := AwardsSummary{Matches: len(awards)}
+	counts := make(map[string]int)
+	for _, a := range awards {
+		for key := range a {
+			counts[key]++
+			summary.Total++
+		}
+	}
+	for key, count := range counts {
+		summary.Awards = append(summary.Awards, AwardCount{Award: key, Name: activision.AwardName(key), Matches: count,
+			Percent: percent(float64(count), float64(summary.Matches))})
+	}
+	sort.Slice(summary.Awards, func(i, j int) bool {
+		if summary.Awards[i].Matches != summary.Awards[j].Matches {
+			return summary.Awards[i].Matches > summary.Awards[j].Matches
+		}
+		return summary.Awards[i].Award < summary.Awards[j].Award
+	})
+	n := topAwards
+	if n > len(summary.Awards) {
+		n = len(summary.Awards)
+	}
+	summary.MostFrequent = summary.Awards[:n]
+	// The rarest are taken only from the awards that are not in the most frequent, a player with few awards will have
+	// short (or empty) rarest list instead of the same awards in both lists.
+	for i := len(summary.Awards) - 1; i >= n && len(summary.Rarest) < topAwards; i-- {
+		summary.Rarest = append(summary.Rarest, summary.Awards[i])
+	}
+	return summary
+}
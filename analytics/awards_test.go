@@ -0,0 +1,47 @@
+package analytics
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// matchesWithAwards return matches where award i was earned in i+1 matches, so award 0 is the rarest.
+func matchesWithAwards(count int) []activision.Awards {
+	matches := make([]activision.Awards, count)
+	for i := range matches {
+		matches[i] = make(activision.Awards)
+		for award := 0; award <= i; award++ {
+			matches[i]["award"+strconv.Itoa(count-1-award)] = 1
+		}
+	}
+	return matches
+}
+
+func TestAggregateAwardsRarestWithoutMostFrequent(t *testing.T) {
+	for _, count := range []int{3, 7, 12} {
+		summary := AggregateAwards(matchesWithAwards(count))
+		frequent := make(map[string]bool)
+		for _, a := range summary.MostFrequent {
+			frequent[a.Award] = true
+		}
+		for _, a := range summary.Rarest {
+			if frequent[a.Award] {
+				t.Errorf("%d awards: %s is in both the most frequent and the rarest", count, a.Award)
+			}
+		}
+		expected := count - topAwards
+		if expected < 0 {
+			expected = 0
+		} else if expected > topAwards {
+			expected = topAwards
+		}
+		if len(summary.Rarest) != expected {
+			t.Errorf("%d awards: expected %d rarest awards, got %d", count, expected, len(summary.Rarest))
+		}
+		if len(summary.Rarest) > 0 && summary.Rarest[0].Award != "award0" {
+			t.Errorf("%d awards: expected award0 to be the rarest, got %s", count, summary.Rarest[0].Award)
+		}
+	}
+}
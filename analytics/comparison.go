@@ -0,0 +1,243 @@
+// This file is responsible for the head to head comparison between players, the comparison is built from the responses
+// that we already fetched for each player and all the metrics are normalized (per game / ratios) so players with different
+// amount of games can be compared.
+
+package analytics
+
+import (
+	"math"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// PlayerSnapshot holds all the data that we fetched for a single player in order to compare him with the other players.
+type PlayerSnapshot struct {
+	Username          string                                `json:"username"`
+	Platform          string                                `json:"platform"`
+	LifetimeAndWeekly *activision.LifetimeAndWeeklyResponse `json:"lifetimeAndWeekly"`
+	LastGames         *activision.LastGamesResponse         `json:"lastGames"`
+}
+
+// MetricComparison is a single line of the comparison, the values are in the same order of the players in the PlayersComparison.
+// Players without data for the metric (NoData is true for them) have value 0 and are left out of the ranking.
+// PercentDifference is how much the winner value is better then the runner up value, when the runner up value is 0
+// the difference is not defined and we leave it 0.
+type MetricComparison struct {
+	Metric            string    `json:"metric"`
+	Values            []float64 `json:"values"`
+	NoData            []bool    `json:"noData,omitempty"` // set only when at least one of the players has no data
+	LowerIsBetter     bool      `json:"lowerIsBetter"`
+	Winner            string    `json:"winner"`      // empty when the best value is shared by more then one player
+	WinnerIndex       int       `json:"winnerIndex"` // the index of the winner in the players, -1 when there is no single winner
+	PercentDifference float64   `json:"percentDifference"`
+}
+
+// PlayersComparison is the side by side comparison of the players, split by the source of the data.
+type PlayersComparison struct {
+	Players   []string           `json:"players"`
+	Games     int                `json:"games"` // the number of recent games used for the LastGames section
+	Lifetime  []MetricComparison `json:"lifetime"`
+	Weekly    []MetricComparison `json:"weekly"`
+	LastGames []MetricComparison `json:"lastGames"`
+}
+
+// metricDefinition describe how to extract one metric value from the player snapshot,
+// hasData is set for metrics that some players may not have (nil means that every player has the metric).
+type metricDefinition struct {
+	name          string
+	lowerIsBetter bool
+	value         func(p PlayerSnapshot) float64
+	hasData       func(p PlayerSnapshot) bool
+}
+
+var lifetimeMetrics = []metricDefinition{
+	{name: "KD ratio", value: func(p PlayerSnapshot) float64 { return lifetimeBr(p).KdRatio }},
+	{name: "Win %", value: func(p PlayerSnapshot) float64 { return percent(lifetimeBr(p).Wins, lifetimeBr(p).GamesPlayed) }},
+	{name: "Top 5 %", value: func(p PlayerSnapshot) float64 { return percent(lifetimeBr(p).TopFive, lifetimeBr(p).GamesPlayed) }},
+	{name: "Top 10 %", value: func(p PlayerSnapshot) float64 { return percent(lifetimeBr(p).TopTen, lifetimeBr(p).GamesPlayed) }},
+	{name: "Kills per game", value: func(p PlayerSnapshot) float64 { return ratio(lifetimeBr(p).Kills, lifetimeBr(p).GamesPlayed) }},
+	{name: "Downs per game", value: func(p PlayerSnapshot) float64 { return ratio(lifetimeBr(p).Downs, lifetimeBr(p).GamesPlayed) }},
+	{name: "Revives per game", value: func(p PlayerSnapshot) float64 { return ratio(lifetimeBr(p).Revives, lifetimeBr(p).GamesPlayed) }},
+	{name: "Score per minute", value: func(p PlayerSnapshot) float64 { return lifetimeBr(p).ScorePerMinute }},
+	{name: "Games played", value: func(p PlayerSnapshot) float64 { return lifetimeBr(p).GamesPlayed }},
+}
+
+var weeklyMetrics = []metricDefinition{
+	{name: "KD ratio", value: func(p PlayerSnapshot) float64 { return weeklyBr(p).KdRatio }},
+	{name: "Kills per game", value: func(p PlayerSnapshot) float64 { return weeklyBr(p).KillsPerGame }},
+	{name: "Damage per game", value: func(p PlayerSnapshot) float64 { return ratio(weeklyBr(p).DamageDone, weeklyBr(p).MatchesPlayed) }},
+	{name: "Headshot %", value: func(p PlayerSnapshot) float64 { return weeklyBr(p).HeadshotPercentage * 100 }},
+	{name: "Gulag win %", value: func(p PlayerSnapshot) float64 {
+		return percent(weeklyBr(p).GulagKills, weeklyBr(p).GulagKills+weeklyBr(p).GulagDeaths)
+	}},
+	{name: "Score per minute", value: func(p PlayerSnapshot) float64 { return weeklyBr(p).ScorePerMinute }},
+	{name: "Matches played", value: func(p PlayerSnapshot) float64 { return weeklyBr(p).MatchesPlayed }},
+}
+
+var lastGamesMetrics = []metricDefinition{
+	{name: "KD ratio", value: func(p PlayerSnapshot) float64 { return averages(lastGames(p)).KdRatio }},
+	{name: "Kills per game", value: func(p PlayerSnapshot) float64 { return averages(lastGames(p)).Kills }},
+	{name: "Damage per game", value: func(p PlayerSnapshot) float64 { return averages(lastGames(p)).DamageDone }},
+	{name: "Average placement", lowerIsBetter: true,
+		value: func(p PlayerSnapshot) float64 {
+			average, _ := averagePlacement(lastGames(p))
+			return average
+		},
+		hasData: func(p PlayerSnapshot) bool {
+			_, ok := averagePlacement(lastGames(p))
+			return ok
+		},
+	},
+	{name: "Wins", value: func(p PlayerSnapshot) float64 { return countPlacements(lastGames(p), 1) }},
+	{name: "Top 10", value: func(p PlayerSnapshot) float64 { return countPlacements(lastGames(p), 10) }},
+}
+
+// ComparePlayersStats build the comparison for the given players snapshots, the LastGames section use only
+// the most recent 'games' matches of each player (all of them in case games <= 0).
+func ComparePlayersStats(players []PlayerSnapshot, games int) PlayersComparison {
+	if games > 0 {
+		trimmed := make([]PlayerSnapshot, len(players))
+		for i, p := range players {
+			trimmed[i] = p
+			if p.LastGames != nil {
+				r := *p.LastGames
+				r.Data.Matches = newestFirst(r.Data.Matches)
+				if len(r.Data.Matches) > games {
+					r.Data.Matches = r.Data.Matches[:games]
+				}
+				trimmed[i].LastGames = &r
+			}
+		}
+		players = trimmed
+	}
+
+	result := PlayersComparison{Games: games}
+	for _, p := range players {
+		result.Players = append(result.Players, p.Username)
+	}
+	result.Lifetime = compareMetrics(players, lifetimeMetrics)
+	result.Weekly = compareMetrics(players, weeklyMetrics)
+	result.LastGames = compareMetrics(players, lastGamesMetrics)
+	return result
+}
+
+func compareMetrics(players []PlayerSnapshot, definitions []metricDefinition) []MetricComparison {
+	comparisons := make([]MetricComparison, 0, len(definitions))
+	for _, d := range definitions {
+		c := MetricComparison{Metric: d.name, LowerIsBetter: d.lowerIsBetter}
+		ranked := make([]bool, len(players))
+		for i, p := range players {
+			ranked[i] = d.hasData == nil || d.hasData(p)
+			value := 0.0
+			if ranked[i] {
+				value = d.value(p)
+			} else {
+				if c.NoData == nil {
+					c.NoData = make([]bool, len(players))
+				}
+				c.NoData[i] = true
+			}
+			c.Values = append(c.Values, value)
+		}
+		best, runnerUp, winner := rankValues(c.Values, ranked, d.lowerIsBetter)
+		c.WinnerIndex = winner
+		if winner >= 0 {
+			c.Winner = players[winner].Username
+		}
+		if runnerUp != 0 {
+			c.PercentDifference = math.Abs(best-runnerUp) / math.Abs(runnerUp) * 100
+		}
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
+
+// rankValues return the best value, the second best value and the index of the winner from the values that are ranked,
+// the winner index is -1 when there is a tie on the best value or when no value is ranked.
+func rankValues(values []float64, ranked []bool, lowerIsBetter bool) (float64, float64, int) {
+	better := func(a, b float64) bool {
+		if lowerIsBetter {
+			return a < b
+		}
+		return a > b
+	}
+	winner := -1
+	for i, v := range values {
+		if ranked[i] && (winner < 0 || better(v, values[winner])) {
+			winner = i
+		}
+	}
+	if winner < 0 {
+		return 0, 0, -1
+	}
+	runnerUp := math.NaN()
+	for i, v := range values {
+		if i == winner || !ranked[i] {
+			continue
+		}
+		if v == values[winner] {
+			return values[winner], v, -1
+		}
+		if math.IsNaN(runnerUp) || better(v, runnerUp) {
+			runnerUp = v
+		}
+	}
+	if math.IsNaN(runnerUp) {
+		return values[winner], 0, winner
+	}
+	return values[winner], runnerUp, winner
+}
+
+func lifetimeBr(p PlayerSnapshot) activision.LifetimeStatsBrModeProperties {
+	if p.LifetimeAndWeekly == nil {
+		return activision.LifetimeStatsBrModeProperties{}
+	}
+	return p.LifetimeAndWeekly.Data.Lifetime.Mode.BattleRoyal.Properties
+}
+
+func weeklyBr(p PlayerSnapshot) activision.WeeklyStatsBrAllModeProperties {
+	if p.LifetimeAndWeekly == nil {
+		return activision.WeeklyStatsBrAllModeProperties{}
+	}
+	return p.LifetimeAndWeekly.Data.Weekly.Mode.BattleRoyalAll.Properties
+}
+
+func lastGames(p PlayerSnapshot) []activision.Match {
+	if p.LastGames == nil {
+		return nil
+	}
+	return p.LastGames.Data.Matches
+}
+
+// averagePlacement return the average team placement of the matches that have placement, false when none of them has.
+// The official API return placement 0 for matches that were not finished, counting them would make the average better.
+func averagePlacement(matches []activision.Match) (float64, bool) {
+	total, count := 0.0, 0
+	for _, m := range matches {
+		if m.PlayerStats.TeamPlacement > 0 {
+			total += m.PlayerStats.TeamPlacement
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+func countPlacements(matches []activision.Match, top float64) float64 {
+	count := 0.0
+	for _, m := range matches {
+		if m.PlayerStats.TeamPlacement > 0 && m.PlayerStats.TeamPlacement <= top {
+			count++
+		}
+	}
+	return count
+}
+
+func percent(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b * 100
+}
@@ -0,0 +1,90 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+func snapshotWithPlacements(username, platform string, placements ...float64) PlayerSnapshot {
+	r := &activision.LastGamesResponse{}
+	for i, p := range placements {
+		r.Data.Matches = append(r.Data.Matches, activision.Match{
+			MatchID:         username + string(rune('a'+i)),
+			UtcStartSeconds: float64(1000 - i),
+			PlayerStats:     activision.PlayerStatsFromMatch{TeamPlacement: p},
+		})
+	}
+	return PlayerSnapshot{Username: username, Platform: platform, LastGames: r}
+}
+
+func findMetric(t *testing.T, metrics []MetricComparison, name string) MetricComparison {
+	t.Helper()
+	for _, m := range metrics {
+		if m.Metric == name {
+			return m
+		}
+	}
+	t.Fatalf("metric %s not found", name)
+	return MetricComparison{}
+}
+
+func TestCompareAveragePlacement(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []PlayerSnapshot
+		values  []float64
+		noData  []bool
+		winner  int
+	}{
+		{
+			name:    "unfinished matches are not counted",
+			players: []PlayerSnapshot{snapshotWithPlacements("a", "uno", 0, 4, 0), snapshotWithPlacements("b", "uno", 3, 5)},
+			values:  []float64{4, 4},
+			winner:  -1,
+		},
+		{
+			name:    "players without placements are not ranked",
+			players: []PlayerSnapshot{snapshotWithPlacements("a", "uno", 0, 0), {Username: "b"}, snapshotWithPlacements("c", "uno", 12)},
+			values:  []float64{0, 0, 12},
+			noData:  []bool{true, true, false},
+			winner:  2,
+		},
+		{
+			name:    "nobody has placements",
+			players: []PlayerSnapshot{snapshotWithPlacements("a", "uno"), snapshotWithPlacements("b", "uno", 0)},
+			values:  []float64{0, 0},
+			noData:  []bool{true, true},
+			winner:  -1,
+		},
+		{
+			name:    "same username on two platforms",
+			players: []PlayerSnapshot{snapshotWithPlacements("same", "psn", 10), snapshotWithPlacements("same", "battle", 2)},
+			values:  []float64{10, 2},
+			winner:  1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := findMetric(t, ComparePlayersStats(test.players, 0).LastGames, "Average placement")
+			for i, v := range test.values {
+				if m.Values[i] != v {
+					t.Errorf("expected values %v, got %v", test.values, m.Values)
+					break
+				}
+			}
+			if len(m.NoData) != len(test.noData) {
+				t.Fatalf("expected no data %v, got %v", test.noData, m.NoData)
+			}
+			for i := range test.noData {
+				if m.NoData[i] != test.noData[i] {
+					t.Errorf("expected no data %v, got %v", test.noData, m.NoData)
+					break
+				}
+			}
+			if m.WinnerIndex != test.winner {
+				t.Errorf("expected winner %d, got %d", test.winner, m.WinnerIndex)
+			}
+		})
+	}
+}
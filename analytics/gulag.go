@@ -0,0 +1,221 @@
+// This file is responsible for the gulag analytics, a match where the player has gulag kill or gulag death is a match
+// where he went to the gulag, a gulag kill means that he won it and came back to the game.
+
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// GulagStats is the gulag record of a group of matches, Reliability is the lower bound of the 95% Wilson interval of the
+// win rate, that way a player that won 1 of 1 gulags is not considered more reliable then a player that won 18 of 20.
+type GulagStats struct {
+	Visits      int     `json:"visits"`
+	Wins        int     `json:"wins"`
+	Losses      int     `json:"losses"`
+	WinRate     float64 `json:"winRate"`     // percent
+	Reliability float64 `json:"reliability"` // percent
+}
+
+// ModeGulagStats is the gulag record of a single mode.
+type ModeGulagStats struct {
+	Mode string `json:"mode"`
+	GulagStats
+}
+
+// DayGulagStats is the gulag record of a single day (UTC).
+type DayGulagStats struct {
+	Day time.Time `json:"day"`
+	GulagStats
+}
+
+// GulagPlacement compare the final placement of the matches by the gulag outcome, Correlation is the Pearson correlation
+// between winning the gulag (1 for win and 0 for loss) and the placement, negative value means that gulag wins come with better placement.
+type GulagPlacement struct {
+	AfterWin    float64 `json:"afterWin"`
+	AfterLoss   float64 `json:"afterLoss"`
+	NoGulag     float64 `json:"noGulag"`
+	Correlation float64 `json:"correlation"`
+}
+
+// GulagReport is the gulag analytics of a single player.
+type GulagReport struct {
+	Player    string           `json:"player"`
+	Matches   int              `json:"matches"`
+	Overall   GulagStats       `json:"overall"`
+	ByMode    []ModeGulagStats `json:"byMode"` // sorted by visits
+	ByDay     []DayGulagStats  `json:"byDay"`  // sorted from the oldest day
+	Placement GulagPlacement   `json:"placement"`
+}
+
+// SquadGulagReport is the gulag analytics of all the squad members, the players are sorted from the most reliable player.
+type SquadGulagReport struct {
+	Overall GulagStats    `json:"overall"`
+	Players []GulagReport `json:"players"`
+}
+
+// gulagOutcome return if the player went to the gulag in the match and if he won.
+func gulagOutcome(m activision.Match) (visited bool, won bool) {
+	if m.PlayerStats.GulagKills > 0 {
+		return true, true
+	}
+	return m.PlayerStats.GulagDeaths > 0, false
+}
+
+// MatchesGulag calculate the gulag analytics of the player matches.
+func MatchesGulag(player string, matches []activision.Match) GulagReport {
+	report := GulagReport{Player: player, Matches: len(matches)}
+	var overall gulagCounter
+	byMode := make(map[string]*gulagCounter)
+	byDay := make(map[time.Time]*gulagCounter)
+	var winPlacements, lossPlacements, noGulagPlacements []float64
+	var outcomes, placements []float64
+	for _, m := range oldestFirst(matches) {
+		visited, won := gulagOutcome(m)
+		placement := m.PlayerStats.TeamPlacement
+		if !visited {
+			if placement > 0 {
+				noGulagPlacements = append(noGulagPlacements, placement)
+			}
+			continue
+		}
+		overall.add(won)
+		counter(byMode, m.Mode).add(won)
+		day := time.Unix(int64(m.UtcStartSeconds), 0).UTC().Truncate(24 * time.Hour)
+		counter(byDay, day).add(won)
+		if placement <= 0 {
+			continue
+		}
+		outcome := 0.0
+		if won {
+			outcome = 1
+			winPlacements = append(winPlacements, placement)
+		} else {
+			lossPlacements = append(lossPlacements, placement)
+		}
+		outcomes = append(outcomes, outcome)
+		placements = append(placements, placement)
+	}
+
+	report.Overall = overall.stats()
+	for mode, c := range byMode {
+		report.ByMode = append(report.ByMode, ModeGulagStats{Mode: mode, GulagStats: c.stats()})
+	}
+	sort.Slice(report.ByMode, func(i, j int) bool {
+		if report.ByMode[i].Visits != report.ByMode[j].Visits {
+			return report.ByMode[i].Visits > report.ByMode[j].Visits
+		}
+		return report.ByMode[i].Mode < report.ByMode[j].Mode
+	})
+	for day, c := range byDay {
+		report.ByDay = append(report.ByDay, DayGulagStats{Day: day, GulagStats: c.stats()})
+	}
+	sort.Slice(report.ByDay, func(i, j int) bool { return report.ByDay[i].Day.Before(report.ByDay[j].Day) })
+	report.Placement = GulagPlacement{
+		AfterWin:    mean(winPlacements),
+		AfterLoss:   mean(lossPlacements),
+		NoGulag:     mean(noGulagPlacements),
+		Correlation: correlation(outcomes, placements),
+	}
+	return report
+}
+
+// SquadGulag calculate the gulag analytics of every squad member and the combined record of the squad,
+// the players argument is the matches of each player by his name.
+func SquadGulag(players map[string][]activision.Match) SquadGulagReport {
+	var report SquadGulagReport
+	var overall gulagCounter
+	for player, matches := range players {
+		r := MatchesGulag(player, matches)
+		overall.wins += r.Overall.Wins
+		overall.losses += r.Overall.Losses
+		report.Players = append(report.Players, r)
+	}
+	report.Overall = overall.stats()
+	sort.Slice(report.Players, func(i, j int) bool {
+		if report.Players[i].Overall.Reliability != report.Players[j].Overall.Reliability {
+			return report.Players[i].Overall.Reliability > report.Players[j].Overall.Reliability
+		}
+		return report.Players[i].Player < report.Players[j].Player
+	})
+	return report
+}
+
+/***************************************** Help functions *****************************************/
+
+type gulagCounter struct {
+	wins   int
+	losses int
+}
+
+func counter[K comparable](counters map[K]*gulagCounter, key K) *gulagCounter {
+	c, ok := counters[key]
+	if !ok {
+		c = &gulagCounter{}
+		counters[key] = c
+	}
+	return c
+}
+
+func (c *gulagCounter) add(won bool) {
+	if won {
+		c.wins++
+	} else {
+		c.losses++
+	}
+}
+
+func (c gulagCounter) stats() GulagStats {
+	visits := c.wins + c.losses
+	return GulagStats{
+		Visits:      visits,
+		Wins:        c.wins,
+		Losses:      c.losses,
+		WinRate:     percent(float64(c.wins), float64(visits)),
+		Reliability: wilsonLowerBound(c.wins, visits) * 100,
+	}
+}
+
+// wilsonLowerBound return the lower bound of the 95% Wilson score interval of wins out of n.
+func wilsonLowerBound(wins int, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	const z = 1.96
+	p := float64(wins) / float64(n)
+	total := float64(n)
+	center := p + z*z/(2*total)
+	margin := z * math.Sqrt((p*(1-p)+z*z/(4*total))/total)
+	return (center - margin) / (1 + z*z/total)
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// correlation return the Pearson correlation of x and y, 0 when one of them is constant.
+func correlation(x []float64, y []float64) float64 {
+	mx, my := mean(x), mean(y)
+	var sxy, sxx, syy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return 0
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
@@ -0,0 +1,198 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// Gulag outcomes of gulagMatch.
+const (
+	noGulag = iota
+	gulagWin
+	gulagLoss
+)
+
+func gulagMatch(mode string, start float64, outcome int, placement float64) activision.Match {
+	m := activision.Match{MatchID: mode + time.Unix(int64(start), 0).String(), Mode: mode, UtcStartSeconds: start,
+		PlayerStats: activision.PlayerStatsFromMatch{TeamPlacement: placement}}
+	switch outcome {
+	case gulagWin:
+		m.PlayerStats.GulagKills = 1
+	case gulagLoss:
+		m.PlayerStats.GulagDeaths = 1
+	}
+	return m
+}
+
+func TestMatchesGulagWinRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		matches []activision.Match
+		want    GulagStats
+	}{
+		{name: "no matches", want: GulagStats{}},
+		{name: "no gulag", matches: []activision.Match{gulagMatch("br", 1, noGulag, 5)}, want: GulagStats{}},
+		{
+			name: "wins and losses",
+			matches: []activision.Match{
+				gulagMatch("br", 1, gulagWin, 5), gulagMatch("br", 2, gulagLoss, 40), gulagMatch("br", 3, gulagWin, 2),
+				gulagMatch("br", 4, gulagWin, 0), gulagMatch("br", 5, noGulag, 1),
+			},
+			want: GulagStats{Visits: 4, Wins: 3, Losses: 1, WinRate: 75},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchesGulag("niv", tt.matches).Overall
+			got.Reliability = 0
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+
+	// A kill and a death in the gulag of the same match (the player won and then died in the second gulag) is a win.
+	m := gulagMatch("br", 1, gulagWin, 3)
+	m.PlayerStats.GulagDeaths = 1
+	if got := MatchesGulag("niv", []activision.Match{m}).Overall; got.Wins != 1 || got.Losses != 0 {
+		t.Errorf("expected the gulag kill to count as a win, got %+v", got)
+	}
+}
+
+func TestMatchesGulagGrouping(t *testing.T) {
+	first := float64(time.Date(2021, 12, 1, 23, 0, 0, 0, time.UTC).Unix())
+	matches := []activision.Match{
+		gulagMatch("br_quads", first+secondsPerDay+60, gulagLoss, 20),
+		gulagMatch("br_quads", first, gulagWin, 1),
+		gulagMatch("br_trios", first+90*60, gulagWin, 3), // the next UTC day
+		gulagMatch("br_quads", first+secondsPerDay, gulagWin, 2),
+		gulagMatch("br_rebirth", first+secondsPerDay, noGulag, 1),
+	}
+	report := MatchesGulag("niv", matches)
+	if report.Matches != 5 {
+		t.Errorf("expected 5 matches, got %d", report.Matches)
+	}
+
+	modes := []struct {
+		mode   string
+		visits int
+		wins   int
+	}{{"br_quads", 3, 2}, {"br_trios", 1, 1}}
+	if len(report.ByMode) != len(modes) {
+		t.Fatalf("expected %d modes, got %+v", len(modes), report.ByMode)
+	}
+	for i, want := range modes {
+		if got := report.ByMode[i]; got.Mode != want.mode || got.Visits != want.visits || got.Wins != want.wins {
+			t.Errorf("mode %d: expected %s with %d of %d, got %+v", i, want.mode, want.wins, want.visits, got)
+		}
+	}
+
+	days := []struct {
+		day    time.Time
+		visits int
+		wins   int
+	}{
+		{time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC), 1, 1},
+		{time.Date(2021, 12, 2, 0, 0, 0, 0, time.UTC), 3, 2},
+	}
+	if len(report.ByDay) != len(days) {
+		t.Fatalf("expected %d days, got %+v", len(days), report.ByDay)
+	}
+	for i, want := range days {
+		if got := report.ByDay[i]; !got.Day.Equal(want.day) || got.Visits != want.visits || got.Wins != want.wins {
+			t.Errorf("day %d: expected %s with %d of %d, got %+v", i, want.day, want.wins, want.visits, got)
+		}
+	}
+}
+
+func TestMatchesGulagPlacement(t *testing.T) {
+	tests := []struct {
+		name    string
+		matches []activision.Match
+		want    GulagPlacement
+	}{
+		{name: "no matches", want: GulagPlacement{}},
+		{
+			name:    "gulag wins come with better placement",
+			matches: []activision.Match{gulagMatch("br", 1, gulagWin, 2), gulagMatch("br", 2, gulagLoss, 30), gulagMatch("br", 3, gulagWin, 4), gulagMatch("br", 4, gulagLoss, 40)},
+			want:    GulagPlacement{AfterWin: 3, AfterLoss: 35, Correlation: -32 / math.Sqrt(1076)},
+		},
+		{
+			name:    "only wins has zero variance",
+			matches: []activision.Match{gulagMatch("br", 1, gulagWin, 2), gulagMatch("br", 2, gulagWin, 30)},
+			want:    GulagPlacement{AfterWin: 16},
+		},
+		{
+			name:    "same placement has zero variance",
+			matches: []activision.Match{gulagMatch("br", 1, gulagWin, 7), gulagMatch("br", 2, gulagLoss, 7)},
+			want:    GulagPlacement{AfterWin: 7, AfterLoss: 7},
+		},
+		{
+			name: "matches without placement are skipped",
+			matches: []activision.Match{gulagMatch("br", 1, gulagWin, 1), gulagMatch("br", 2, gulagLoss, 0), gulagMatch("br", 3, gulagLoss, 9),
+				gulagMatch("br", 4, noGulag, 10), gulagMatch("br", 5, noGulag, 0), gulagMatch("br", 6, noGulag, 20)},
+			want: GulagPlacement{AfterWin: 1, AfterLoss: 9, NoGulag: 15, Correlation: -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchesGulag("niv", tt.matches).Placement
+			if math.Abs(got.Correlation-tt.want.Correlation) > 1e-9 {
+				t.Errorf("expected correlation %v, got %v", tt.want.Correlation, got.Correlation)
+			}
+			got.Correlation = tt.want.Correlation
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSquadGulagReliability(t *testing.T) {
+	gulags := func(wins, losses int) []activision.Match {
+		var matches []activision.Match
+		for i := 0; i < wins+losses; i++ {
+			outcome := gulagWin
+			if i >= wins {
+				outcome = gulagLoss
+			}
+			matches = append(matches, gulagMatch("br", float64(i), outcome, 10))
+		}
+		return matches
+	}
+	report := SquadGulag(map[string][]activision.Match{
+		"lucky":    gulags(1, 0),
+		"reliable": gulags(18, 2),
+		"bad":      gulags(2, 8),
+		"never":    nil,
+	})
+
+	// The player that won 1 of 1 has higher win rate but less reliable than the player that won 18 of 20.
+	tests := []struct {
+		player      string
+		winRate     float64
+		reliability float64
+	}{
+		{player: "reliable", winRate: 90, reliability: 69.896},
+		{player: "lucky", winRate: 100, reliability: 20.654},
+		{player: "bad", winRate: 20, reliability: 5.668},
+		{player: "never", winRate: 0, reliability: 0},
+	}
+	if len(report.Players) != len(tests) {
+		t.Fatalf("expected %d players, got %d", len(tests), len(report.Players))
+	}
+	for i, tt := range tests {
+		got := report.Players[i]
+		if got.Player != tt.player || got.Overall.WinRate != tt.winRate || math.Abs(got.Overall.Reliability-tt.reliability) > 0.001 {
+			t.Errorf("player %d: expected %s with win rate %v and reliability %v, got %s with %+v", i, tt.player, tt.winRate, tt.reliability, got.Player, got.Overall)
+		}
+	}
+	if report.Overall.Visits != 31 || report.Overall.Wins != 21 || report.Overall.Losses != 10 {
+		t.Errorf("expected the squad to win 21 of 31, got %+v", report.Overall)
+	}
+}
@@ -0,0 +1,192 @@
+// This file is responsible for ranking the tracked players, the leaderboards are calculated from the matches that the
+// watch mode saved and from the lifetime snapshots of the players.
+
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// The windows of the leaderboards.
+const (
+	WindowToday    = "today"
+	WindowWeek     = "week"
+	WindowLast50   = "last50"
+	WindowLifetime = "lifetime"
+)
+
+// The metrics of the leaderboards.
+const (
+	MetricKd            = "kd"
+	MetricWins          = "wins"
+	MetricDamagePerGame = "damage"
+	MetricKillsPerGame  = "kills"
+	MetricGulagWinRate  = "gulag"
+)
+
+// Windows and Metrics are the supported values, in the order they are shown in the usage.
+var (
+	Windows = []string{WindowToday, WindowWeek, WindowLast50, WindowLifetime}
+	Metrics = []string{MetricKd, MetricWins, MetricDamagePerGame, MetricKillsPerGame, MetricGulagWinRate}
+)
+
+// LeaderboardPlayer holds the data of a single tracked player, Lifetime is needed only for the lifetime window.
+// LifetimeFailed is set when we failed to fetch the lifetime stats, the player is then ranked by his saved matches.
+type LeaderboardPlayer struct {
+	Player         string                                `json:"player"` // "platform:username"
+	Matches        []activision.Match                    `json:"matches"`
+	Lifetime       *activision.LifetimeAndWeeklyResponse `json:"lifetime"`
+	LifetimeFailed bool                                  `json:"lifetimeFailed"`
+}
+
+// LeaderboardEntry is a single line of the leaderboard, players with the same value share the same rank.
+// LifetimeFailed is true when the lifetime window value was calculated from the saved matches because the lifetime stats fetch failed.
+type LeaderboardEntry struct {
+	Rank           int     `json:"rank"`
+	Player         string  `json:"player"`
+	Value          float64 `json:"value"`
+	Games          int     `json:"games"`
+	LifetimeFailed bool    `json:"lifetimeFailed,omitempty"`
+}
+
+// Leaderboard is the ranking of the players for one metric over one window.
+type Leaderboard struct {
+	Window  string             `json:"window"`
+	Metric  string             `json:"metric"`
+	Since   time.Time          `json:"since,omitempty"` // the start of the today and week windows
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// BuildLeaderboard rank the players by the metric over the window, now is the time that the today and week windows are calculated from
+// (the week starts on Monday). Players without games in the window are not included.
+// The lifetime window use the lifetime snapshot for the metrics that it has (KD, wins and kills per game), the damage and
+// the gulag are missing from the lifetime stats of the official API so for them we use all the saved matches.
+func BuildLeaderboard(players []LeaderboardPlayer, window string, metric string, now time.Time) (Leaderboard, error) {
+	if err := ValidateLeaderboard(window, metric); err != nil {
+		return Leaderboard{}, err
+	}
+	board := Leaderboard{Window: window, Metric: metric}
+	switch window {
+	case WindowToday:
+		board.Since = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	case WindowWeek:
+		daysSinceMonday := (int(now.Weekday()) + 6) % 7
+		board.Since = time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday, 0, 0, 0, 0, now.Location())
+	}
+
+	for _, p := range players {
+		var value float64
+		var games int
+		lifetimeMetricUsed := window == WindowLifetime && metric != MetricDamagePerGame && metric != MetricGulagWinRate
+		if lifetimeMetricUsed && p.Lifetime != nil {
+			value, games = lifetimeMetric(p.Lifetime.Data.Lifetime.Mode.BattleRoyal.Properties, metric)
+		} else {
+			matches := windowMatches(newestFirst(p.Matches), window, board.Since)
+			value, games = matchesMetric(matches, metric), len(matches)
+		}
+		if games == 0 {
+			continue
+		}
+		board.Entries = append(board.Entries, LeaderboardEntry{Player: p.Player, Value: value, Games: games,
+			LifetimeFailed: lifetimeMetricUsed && p.LifetimeFailed})
+	}
+
+	sort.SliceStable(board.Entries, func(i, j int) bool {
+		if board.Entries[i].Value != board.Entries[j].Value {
+			return board.Entries[i].Value > board.Entries[j].Value
+		}
+		return board.Entries[i].Player < board.Entries[j].Player
+	})
+	for i := range board.Entries {
+		if i > 0 && board.Entries[i].Value == board.Entries[i-1].Value {
+			board.Entries[i].Rank = board.Entries[i-1].Rank
+		} else {
+			board.Entries[i].Rank = i + 1
+		}
+	}
+	return board, nil
+}
+
+// windowMatches return the matches of the window, the matches must be sorted from the newest match.
+func windowMatches(matches []activision.Match, window string, since time.Time) []activision.Match {
+	switch window {
+	case WindowLast50:
+		if len(matches) > 50 {
+			return matches[:50]
+		}
+		return matches
+	case WindowToday, WindowWeek:
+		var result []activision.Match
+		for _, m := range matches {
+			if m.UtcStartSeconds >= float64(since.Unix()) {
+				result = append(result, m)
+			}
+		}
+		return result
+	}
+	return matches
+}
+
+func matchesMetric(matches []activision.Match, metric string) float64 {
+	var kills, deaths, damage, wins, gulagKills, gulagDeaths float64
+	for _, m := range matches {
+		kills += m.PlayerStats.Kills
+		deaths += m.PlayerStats.Deaths
+		damage += m.PlayerStats.DamageDone
+		gulagKills += m.PlayerStats.GulagKills
+		gulagDeaths += m.PlayerStats.GulagDeaths
+		if m.PlayerStats.TeamPlacement == 1 {
+			wins++
+		}
+	}
+	games := float64(len(matches))
+	switch metric {
+	case MetricKd:
+		return ratio(kills, deaths)
+	case MetricWins:
+		return wins
+	case MetricDamagePerGame:
+		return ratio(damage, games)
+	case MetricKillsPerGame:
+		return ratio(kills, games)
+	case MetricGulagWinRate:
+		return percent(gulagKills, gulagKills+gulagDeaths)
+	}
+	return 0
+}
+
+func lifetimeMetric(lifetime activision.LifetimeStatsBrModeProperties, metric string) (float64, int) {
+	games := int(lifetime.GamesPlayed)
+	switch metric {
+	case MetricKd:
+		return lifetime.KdRatio, games
+	case MetricWins:
+		return lifetime.Wins, games
+	case MetricKillsPerGame:
+		return ratio(lifetime.Kills, lifetime.GamesPlayed), games
+	}
+	return 0, games
+}
+
+// ValidateLeaderboard return ValidationError when the window or the metric is not supported.
+func ValidateLeaderboard(window string, metric string) error {
+	if !contains(Windows, window) {
+		return activision.NewValidationError("window", window, "window must be one of today, week, last50 or lifetime")
+	}
+	if !contains(Metrics, metric) {
+		return activision.NewValidationError("metric", metric, "metric must be one of kd, wins, damage, kills or gulag")
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
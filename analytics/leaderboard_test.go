@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+func TestLeaderboardMarksLifetimeFallback(t *testing.T) {
+	var lifetime activision.LifetimeAndWeeklyResponse
+	lifetime.Data.Lifetime.Mode.BattleRoyal.Properties = activision.LifetimeStatsBrModeProperties{KdRatio: 1.5, GamesPlayed: 100}
+	matches := []activision.Match{{MatchID: "1", PlayerStats: activision.PlayerStatsFromMatch{Kills: 4, Deaths: 1}}}
+	players := []LeaderboardPlayer{
+		{Player: "uno:a", Matches: matches, Lifetime: &lifetime},
+		{Player: "uno:b", Matches: matches, LifetimeFailed: true},
+	}
+
+	board, err := BuildLeaderboard(players, WindowLifetime, MetricKd, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(board.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(board.Entries))
+	}
+	for _, e := range board.Entries {
+		switch e.Player {
+		case "uno:a":
+			if e.LifetimeFailed || e.Value != 1.5 || e.Games != 100 {
+				t.Errorf("expected the lifetime kd, got %+v", e)
+			}
+		case "uno:b":
+			if !e.LifetimeFailed || e.Value != 4 || e.Games != 1 {
+				t.Errorf("expected the saved matches kd marked as fallback, got %+v", e)
+			}
+		}
+	}
+
+	// The damage is always calculated from the saved matches so it is not a fallback.
+	board, err = BuildLeaderboard(players, WindowLifetime, MetricDamagePerGame, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range board.Entries {
+		if e.LifetimeFailed {
+			t.Errorf("expected no fallback for the damage metric, got %+v", e)
+		}
+	}
+}
+
+func TestValidateLeaderboard(t *testing.T) {
+	tests := []struct {
+		window string
+		metric string
+		field  string
+	}{
+		{window: WindowWeek, metric: MetricKd},
+		{window: WindowLifetime, metric: MetricGulagWinRate},
+		{window: "month", metric: MetricKd, field: "window"},
+		{window: "", metric: MetricWins, field: "window"},
+		{window: WindowToday, metric: "score", field: "metric"},
+		{window: "month", metric: "score", field: "window"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.window+"/"+tt.metric, func(t *testing.T) {
+			err := ValidateLeaderboard(tt.window, tt.metric)
+			if tt.field == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			validation, ok := err.(*activision.ValidationError)
+			if !ok || validation.Field != tt.field {
+				t.Fatalf("expected validation error of %s, got %v", tt.field, err)
+			}
+			if _, err := BuildLeaderboard(nil, tt.window, tt.metric, time.Now()); err == nil {
+				t.Error("expected BuildLeaderboard to reject it too")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// This file is responsible for joining a match from the last games response with the full lobby of the match,
+// the last games response contains only the stats of the requesting player, so in order to know what the whole team did
+// we need the response of GetGameStatsByID for every match.
+
+package analytics
+
+import "github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+
+// LobbyAggregates is the summary of all the players of the match and the place of the player team in the lobby.
+type LobbyAggregates struct {
+	Players       int     `json:"players"`
+	Teams         int     `json:"teams"`
+	AverageKills  float64 `json:"averageKills"`
+	AverageDamage float64 `json:"averageDamage"`
+	AverageKd     float64 `json:"averageKd"`
+	TopKills      float64 `json:"topKills"`
+	TopKiller     string  `json:"topKiller"`
+	TeamKills     float64 `json:"teamKills"`
+	TeamDamage    float64 `json:"teamDamage"`
+	KillsRank     int     `json:"killsRank"`     // the rank of the player by kills in the lobby, 1 is the top killer
+	TeamKillsRank int     `json:"teamKillsRank"` // the rank of the player team by the team kills, 1 is the team with most kills
+}
+
+// EnrichedMatch is a match of the last games response with the stats of the teammates and of the lobby,
+// Error is set instead of them when we failed to receive the full match.
+type EnrichedMatch struct {
+	activision.Match
+	Teammates []activision.PlayerGeneralStatsFromSpecificGame `json:"teammates"`
+	Lobby     *LobbyAggregates                                `json:"lobby,omitempty"`
+	Error     string                                          `json:"error,omitempty"`
+}
+
+// EnrichMatch join the match with the full match response, the player is identified by the Uno ID of the match player details.
+func EnrichMatch(m activision.Match, full activision.SpecificGameStatsResponse) EnrichedMatch {
+	result := EnrichedMatch{Match: m}
+	player, ok := FindPlayer(full, m.Player.Uno)
+	if !ok {
+		result.Error = "the player was not found in the match lobby"
+		return result
+	}
+	result.Teammates = Teammates(full, m.Player.Uno)
+	lobby := LobbyStats(full, player.Player.Team)
+	lobby.KillsRank = 1
+	for _, p := range full.Data.AllPlayers {
+		if p.PlayerStats.Kills > player.PlayerStats.Kills {
+			lobby.KillsRank++
+		}
+	}
+	result.Lobby = &lobby
+	return result
+}
+
+// LobbyStats calculate the aggregates of all the players of the match, the team fields are calculated for the given team.
+func LobbyStats(full activision.SpecificGameStatsResponse, team string) LobbyAggregates {
+	var lobby LobbyAggregates
+	var kills, damage, kd float64
+	for _, p := range full.Data.AllPlayers {
+		lobby.Players++
+		kills += p.PlayerStats.Kills
+		damage += p.PlayerStats.DamageDone
+		kd += p.PlayerStats.KdRatio
+		if p.PlayerStats.Kills > lobby.TopKills {
+			lobby.TopKills = p.PlayerStats.Kills
+			lobby.TopKiller = p.Player.Username
+		}
+	}
+	lobby.AverageKills = ratio(kills, float64(lobby.Players))
+	lobby.AverageDamage = ratio(damage, float64(lobby.Players))
+	lobby.AverageKd = ratio(kd, float64(lobby.Players))
+
+	teams := full.Teams()
+	lobby.Teams = len(teams)
+	for _, t := range teams {
+		if t.Team == team {
+			lobby.TeamKills = t.Kills
+			lobby.TeamDamage = t.DamageDone
+		}
+	}
+	lobby.TeamKillsRank = 1
+	for _, t := range teams {
+		if t.Kills > lobby.TeamKills {
+			lobby.TeamKillsRank++
+		}
+	}
+	return lobby
+}
@@ -0,0 +1,75 @@
+// This file is responsible for the performance breakdown by map.
+
+package analytics
+
+import (
+	"sort"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// MapStats is the performance on a single map, for a squad the games and the placements are counted once for every match
+// and the kills and the deaths are the sum of all the squad members.
+type MapStats struct {
+	Map              string  `json:"map"`
+	Name             string  `json:"name"`
+	Games            int     `json:"games"`
+	Wins             int     `json:"wins"`
+	WinPercent       float64 `json:"winPercent"`
+	AveragePlacement float64 `json:"averagePlacement"`
+	Kills            float64 `json:"kills"`
+	Deaths           float64 `json:"deaths"`
+	Kd               float64 `json:"kd"`
+}
+
+// MatchesByMap return the performance of the player on every map, sorted from the most played map.
+func MatchesByMap(matches []activision.Match) []MapStats {
+	return SquadMatchesByMap(map[string][]activision.Match{"": matches})
+}
+
+// SquadMatchesByMap return the performance of the squad on every map, the players argument is the matches of each player
+// by his name, matches that more then one squad member played are counted once.
+func SquadMatchesByMap(players map[string][]activision.Match) []MapStats {
+	byMap := make(map[string]*MapStats)
+	placements := make(map[string]float64)
+	placed := make(map[string]int)
+	seen := make(map[string]bool)
+	for _, matches := range players {
+		for _, m := range matches {
+			s, ok := byMap[m.Map]
+			if !ok {
+				s = &MapStats{Map: m.Map, Name: activision.MapName(m.Map)}
+				byMap[m.Map] = s
+			}
+			s.Kills += m.PlayerStats.Kills
+			s.Deaths += m.PlayerStats.Deaths
+			if seen[m.MatchID] {
+				continue
+			}
+			seen[m.MatchID] = true
+			s.Games++
+			// Matches without placement (the official API return 0 for some of them) are not part of the average.
+			if m.PlayerStats.TeamPlacement > 0 {
+				placements[m.Map] += m.PlayerStats.TeamPlacement
+				placed[m.Map]++
+			}
+			if m.PlayerStats.TeamPlacement == 1 {
+				s.Wins++
+			}
+		}
+	}
+	result := make([]MapStats, 0, len(byMap))
+	for code, s := range byMap {
+		s.WinPercent = percent(float64(s.Wins), float64(s.Games))
+		s.AveragePlacement = ratio(placements[code], float64(placed[code]))
+		s.Kd = ratio(s.Kills, s.Deaths)
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Games != result[j].Games {
+			return result[i].Games > result[j].Games
+		}
+		return result[i].Map < result[j].Map
+	})
+	return result
+}
@@ -0,0 +1,67 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+func mapMatch(id, code string, placement, kills, deaths float64) activision.Match {
+	return activision.Match{MatchID: id, Map: code, PlayerStats: activision.PlayerStatsFromMatch{TeamPlacement: placement, Kills: kills, Deaths: deaths}}
+}
+
+func TestSquadMatchesByMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		players map[string][]activision.Match
+		want    []MapStats
+	}{
+		{
+			name: "single player",
+			players: map[string][]activision.Match{"niv": {
+				mapMatch("1", "mp_don4", 1, 5, 2), mapMatch("2", "mp_don4", 3, 3, 4), mapMatch("3", "mp_escape4", 10, 2, 2),
+			}},
+			want: []MapStats{
+				{Map: "mp_don4", Name: "Verdansk", Games: 2, Wins: 1, WinPercent: 50, AveragePlacement: 2, Kills: 8, Deaths: 6, Kd: 8.0 / 6},
+				{Map: "mp_escape4", Name: "Rebirth Island", Games: 1, AveragePlacement: 10, Kills: 2, Deaths: 2, Kd: 1},
+			},
+		},
+		{
+			name: "placement 0 is not part of the average",
+			players: map[string][]activision.Match{"niv": {
+				mapMatch("1", "mp_don4", 0, 1, 1), mapMatch("2", "mp_don4", 4, 1, 1), mapMatch("3", "mp_don4", -1, 1, 1),
+			}},
+			want: []MapStats{{Map: "mp_don4", Name: "Verdansk", Games: 3, AveragePlacement: 4, Kills: 3, Deaths: 3, Kd: 1}},
+		},
+		{
+			name:    "map without placements",
+			players: map[string][]activision.Match{"niv": {mapMatch("1", "mp_wz_island", 0, 2, 0)}},
+			want:    []MapStats{{Map: "mp_wz_island", Name: "Caldera", Games: 1, Kills: 2, Kd: 2}},
+		},
+		{
+			name: "squad match counted once",
+			players: map[string][]activision.Match{
+				"niv": {mapMatch("1", "mp_don4", 1, 5, 1), mapMatch("2", "mp_wz_new", 7, 1, 1)},
+				"bar": {mapMatch("1", "mp_don4", 1, 3, 1)},
+			},
+			want: []MapStats{
+				{Map: "mp_don4", Name: "Verdansk", Games: 1, Wins: 1, WinPercent: 100, AveragePlacement: 1, Kills: 8, Deaths: 2, Kd: 4},
+				{Map: "mp_wz_new", Name: "mp_wz_new", Games: 1, AveragePlacement: 7, Kills: 1, Deaths: 1, Kd: 1},
+			},
+		},
+		{name: "no matches", players: map[string][]activision.Match{"niv": nil}, want: []MapStats{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SquadMatchesByMap(tt.players)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d maps, got %+v", len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("map %d: expected %+v, got %+v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
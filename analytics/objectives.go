@@ -0,0 +1,117 @@
+// This file is responsible for the objectives aggregation of the squad, it answers who is doing the looting,
+// the buybacks, the revives and the contracts in the squad.
+
+package analytics
+
+import (
+	"sort"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// PlayerObjectives is the sum of the objectives counters and the contracts of one player over the matches he played with the squad.
+type PlayerObjectives struct {
+	Uno              string                `json:"uno"`
+	Username         string                `json:"username"`
+	Matches          int                   `json:"matches"`
+	CacheOpens       float64               `json:"cacheOpens"`
+	KioskBuys        float64               `json:"kioskBuys"`
+	Revives          float64               `json:"revives"`
+	TabletPickups    float64               `json:"tabletPickups"`
+	TeamWipes        float64               `json:"teamWipes"`
+	MissionsComplete float64               `json:"missionsComplete"`
+	MissionXp        float64               `json:"missionXp"`
+	Objectives       activision.Objectives `json:"objectives"` // all the counters, including the typed ones
+
+	lastSeen float64
+}
+
+// SquadRoles name the squad member that leads every objective, empty when nobody did it.
+type SquadRoles struct {
+	Looter    string `json:"looter"`    // most cache opens
+	Shopper   string `json:"shopper"`   // most buy station purchases
+	Medic     string `json:"medic"`     // most revives
+	Contracts string `json:"contracts"` // most completed contracts
+	Wiper     string `json:"wiper"`     // most team wipes
+}
+
+// SquadObjectivesReport is the objectives of the player and every teammate that played with him, sorted by the number of matches.
+type SquadObjectivesReport struct {
+	Uno     string             `json:"uno"`
+	Matches int                `json:"matches"`
+	Players []PlayerObjectives `json:"players"`
+	Roles   SquadRoles         `json:"roles"`
+}
+
+// MatchesObjectives aggregate the objectives of the player with the given Uno ID and of his teammates over the full match responses.
+func MatchesObjectives(matches []activision.SpecificGameStatsResponse, uno string) SquadObjectivesReport {
+	report := SquadObjectivesReport{Uno: uno}
+	players := make(map[string]*PlayerObjectives)
+	for _, match := range matches {
+		team, ok := match.TeamOf(uno)
+		if !ok {
+			continue
+		}
+		report.Matches++
+		for _, p := range team.Members {
+			addObjectives(players, p)
+		}
+	}
+	for _, p := range players {
+		report.Players = append(report.Players, *p)
+	}
+	sort.Slice(report.Players, func(i, j int) bool {
+		if report.Players[i].Matches != report.Players[j].Matches {
+			return report.Players[i].Matches > report.Players[j].Matches
+		}
+		return report.Players[i].Username < report.Players[j].Username
+	})
+	report.Roles = SquadRoles{
+		Looter:    leader(report.Players, func(p PlayerObjectives) float64 { return p.CacheOpens }),
+		Shopper:   leader(report.Players, func(p PlayerObjectives) float64 { return p.KioskBuys }),
+		Medic:     leader(report.Players, func(p PlayerObjectives) float64 { return p.Revives }),
+		Contracts: leader(report.Players, func(p PlayerObjectives) float64 { return p.MissionsComplete }),
+		Wiper:     leader(report.Players, func(p PlayerObjectives) float64 { return p.TeamWipes }),
+	}
+	return report
+}
+
+func addObjectives(players map[string]*PlayerObjectives, p activision.PlayerGeneralStatsFromSpecificGame) {
+	o, ok := players[p.Player.Uno]
+	if !ok {
+		o = &PlayerObjectives{Uno: p.Player.Uno, Objectives: make(activision.Objectives)}
+		players[p.Player.Uno] = o
+	}
+	o.Matches++
+	if p.UtcStartSeconds >= o.lastSeen {
+		o.lastSeen = p.UtcStartSeconds
+		o.Username = p.Player.Username
+	}
+	o.CacheOpens += p.PlayerStats.ObjectiveBrCacheOpen
+	o.KioskBuys += p.PlayerStats.ObjectiveBrKioskBuy
+	o.Revives += p.PlayerStats.ObjectiveReviver
+	o.TabletPickups += p.PlayerStats.ObjectiveBrMissionPickupTablet
+	o.TeamWipes += p.PlayerStats.ObjectiveTeamWiped
+	o.MissionsComplete += p.Player.BrMissionStats.MissionsComplete
+	o.MissionXp += p.Player.BrMissionStats.TotalMissionXpEarned
+	for key, value := range p.PlayerStats.Objectives {
+		o.Objectives[key] += value
+	}
+}
+
+// leader return the username of the player with the highest value, empty when all the values are 0 or the first place is shared.
+func leader(players []PlayerObjectives, value func(p PlayerObjectives) float64) string {
+	best, name, shared := 0.0, "", false
+	for _, p := range players {
+		v := value(p)
+		if v > best {
+			best, name, shared = v, p.Username, false
+		} else if v == best && v > 0 {
+			shared = true
+		}
+	}
+	if shared {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,129 @@
+// This file is responsible for finding the players that we played with and against, it works on the full match responses
+// that we receive from GetGameStatsByID and identify the players by their Uno ID because usernames can be changed.
+
+package analytics
+
+import (
+	"sort"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// DefaultMinOpponentMatches is the minimum number of matches that we need to meet an opponent before we report him as recurring opponent,
+// in a lobby of 150 players almost everyone is met once so there is no point in reporting them.
+const DefaultMinOpponentMatches = 2
+
+// Participant is a player that we met in one or more matches.
+type Participant struct {
+	Uno      string   `json:"uno"`
+	Username string   `json:"username"` // the username from the most recent match
+	Matches  int      `json:"matches"`
+	MatchIDs []string `json:"matchIDs"`
+
+	lastSeen float64
+}
+
+// ParticipantsReport is the aggregation of the players that the player met over many matches, both lists are sorted
+// from the most frequent player.
+type ParticipantsReport struct {
+	Uno       string        `json:"uno"`
+	Matches   int           `json:"matches"` // the number of matches the player was found in
+	Teammates []Participant `json:"teammates"`
+	Opponents []Participant `json:"opponents"`
+}
+
+// FindPlayer return the stats of the player with the given Uno ID from the match, the second return value is false when the player not found.
+func FindPlayer(match activision.SpecificGameStatsResponse, uno string) (activision.PlayerGeneralStatsFromSpecificGame, bool) {
+	for _, p := range match.Data.AllPlayers {
+		if p.Player.Uno == uno {
+			return p, true
+		}
+	}
+	return activision.PlayerGeneralStatsFromSpecificGame{}, false
+}
+
+// Teammates return the players that played in the same team of the player with the given Uno ID, the player himself is not included,
+// when the player is not part of the match we return nil.
+func Teammates(match activision.SpecificGameStatsResponse, uno string) []activision.PlayerGeneralStatsFromSpecificGame {
+	player, ok := FindPlayer(match, uno)
+	if !ok {
+		return nil
+	}
+	var teammates []activision.PlayerGeneralStatsFromSpecificGame
+	for _, p := range match.Data.AllPlayers {
+		if p.Player.Team == player.Player.Team && p.Player.Uno != uno {
+			teammates = append(teammates, p)
+		}
+	}
+	return teammates
+}
+
+// TopTeams return the n best placed teams of the match, in case n <= 0 all the teams will be returned.
+func TopTeams(match activision.SpecificGameStatsResponse, n int) []activision.MatchTeam {
+	teams := match.Teams()
+	if n > 0 && n < len(teams) {
+		teams = teams[:n]
+	}
+	return teams
+}
+
+// MatchParticipants aggregate the teammates and the opponents of the player with the given Uno ID over all the matches,
+// opponents that we met less then minOpponentMatches times are not included (DefaultMinOpponentMatches is used when minOpponentMatches <= 0).
+func MatchParticipants(matches []activision.SpecificGameStatsResponse, uno string, minOpponentMatches int) ParticipantsReport {
+	if minOpponentMatches <= 0 {
+		minOpponentMatches = DefaultMinOpponentMatches
+	}
+	report := ParticipantsReport{Uno: uno}
+	teammates := make(map[string]*Participant)
+	opponents := make(map[string]*Participant)
+	for _, match := range matches {
+		player, ok := FindPlayer(match, uno)
+		if !ok {
+			continue
+		}
+		report.Matches++
+		for _, p := range match.Data.AllPlayers {
+			if p.Player.Uno == uno || p.Player.Uno == "" {
+				continue
+			}
+			if p.Player.Team == player.Player.Team {
+				addParticipant(teammates, p)
+			} else {
+				addParticipant(opponents, p)
+			}
+		}
+	}
+	report.Teammates = sortedParticipants(teammates, 1)
+	report.Opponents = sortedParticipants(opponents, minOpponentMatches)
+	return report
+}
+
+func addParticipant(participants map[string]*Participant, p activision.PlayerGeneralStatsFromSpecificGame) {
+	participant, ok := participants[p.Player.Uno]
+	if !ok {
+		participant = &Participant{Uno: p.Player.Uno}
+		participants[p.Player.Uno] = participant
+	}
+	participant.Matches++
+	participant.MatchIDs = append(participant.MatchIDs, p.MatchID)
+	if p.UtcStartSeconds >= participant.lastSeen {
+		participant.lastSeen = p.UtcStartSeconds
+		participant.Username = p.Player.Username
+	}
+}
+
+func sortedParticipants(participants map[string]*Participant, minMatches int) []Participant {
+	var result []Participant
+	for _, p := range participants {
+		if p.Matches >= minMatches {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Matches != result[j].Matches {
+			return result[i].Matches > result[j].Matches
+		}
+		return result[i].Uno < result[j].Uno
+	})
+	return result
+}
@@ -0,0 +1,216 @@
+package analytics
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// The players of the winning team (team_twelve) and the second team (team_ten) of the saved full match.
+const (
+	fixturePlayer = "15044895497313027140" // IMVIRTUOUS
+	fixtureMate1  = "17085187262713376119" // Majestic7
+	fixtureMate2  = "1504284439068849125"  // natezarauz
+	fixtureMate3  = "2066176501438595005"  // Aquiles vaesa
+	fixtureRival1 = "10644579546070434476" // Florezz
+	fixtureRival2 = "11648032739243249450" // Korioto
+	fixtureRival3 = "8040933206430441414"  // john_oniichan
+	fixtureRival4 = "5431950264078874479"  // Capitan Pigua
+)
+
+func loadFullMatchFixture(t *testing.T) activision.SpecificGameStatsResponse {
+	t.Helper()
+	data, err := os.ReadFile("../domain/activision/OfficialResponsesFromActiApi/successResponseFromMatchID.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture struct {
+		Data activision.SpecificGameStatsResponse `json:"data"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	return fixture.Data
+}
+
+// copyMatch return the match with a new ID and start time, only the players of the given teams are kept and edit
+// is called on every player.
+func copyMatch(match activision.SpecificGameStatsResponse, id string, start float64, teams []string,
+	edit func(p *activision.PlayerGeneralStatsFromSpecificGame)) activision.SpecificGameStatsResponse {
+	result := activision.SpecificGameStatsResponse{Status: match.Status}
+	for _, p := range match.Data.AllPlayers {
+		keep := len(teams) == 0
+		for _, team := range teams {
+			keep = keep || p.Player.Team == team
+		}
+		if !keep {
+			continue
+		}
+		p.MatchID, p.UtcStartSeconds = id, start
+		if edit != nil {
+			edit(&p)
+		}
+		result.Data.AllPlayers = append(result.Data.AllPlayers, p)
+	}
+	return result
+}
+
+func unos(participants []Participant) string {
+	var result []string
+	for _, p := range participants {
+		result = append(result, p.Uno)
+	}
+	return strings.Join(result, ",")
+}
+
+func TestTeammates(t *testing.T) {
+	match := loadFullMatchFixture(t)
+	tests := []struct {
+		name string
+		uno  string
+		want []string
+	}{
+		{name: "winning team", uno: fixturePlayer, want: []string{fixtureMate1, fixtureMate2, fixtureMate3}},
+		{name: "second team", uno: fixtureRival4, want: []string{fixtureRival1, fixtureRival2, fixtureRival3}},
+		{name: "player not in the match", uno: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teammates := Teammates(match, tt.uno)
+			var got []string
+			for _, p := range teammates {
+				got = append(got, p.Player.Uno)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected teammates %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTopTeams(t *testing.T) {
+	match := loadFullMatchFixture(t)
+	all := len(match.Teams())
+	tests := []struct {
+		name  string
+		n     int
+		teams int
+	}{
+		{name: "top two", n: 2, teams: 2},
+		{name: "all the teams", n: 0, teams: all},
+		{name: "more than the teams", n: all + 10, teams: all},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams := TopTeams(match, tt.n)
+			if len(teams) != tt.teams {
+				t.Fatalf("expected %d teams, got %d", tt.teams, len(teams))
+			}
+			if teams[0].Team != "team_twelve" || teams[0].Placement != 1 || teams[1].Team != "team_ten" || teams[1].Placement != 2 {
+				t.Errorf("expected team_twelve and team_ten first, got %s (%v) and %s (%v)", teams[0].Team, teams[0].Placement, teams[1].Team, teams[1].Placement)
+			}
+			for i := 1; i < len(teams); i++ {
+				if teams[i].Placement != 0 && teams[i].Placement < teams[i-1].Placement {
+					t.Fatalf("teams are not sorted by placement: %v after %v", teams[i].Placement, teams[i-1].Placement)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchParticipants(t *testing.T) {
+	fixture := loadFullMatchFixture(t)
+	start := fixture.Data.AllPlayers[0].UtcStartSeconds
+	// The first match is the full lobby.
+	first := copyMatch(fixture, "m1", start, nil, nil)
+	// In the second match Florezz played in our team, Majestic7 changed his username and Korioto has no Uno ID.
+	second := copyMatch(fixture, "m2", start+3600, []string{"team_twelve", "team_ten"}, func(p *activision.PlayerGeneralStatsFromSpecificGame) {
+		switch p.Player.Uno {
+		case fixtureRival1:
+			p.Player.Team = "team_twelve"
+		case fixtureMate1:
+			p.Player.Username = "Majestic8"
+		case fixtureRival2:
+			p.Player.Uno = ""
+		}
+	})
+	// The third match is newer but the player did not play in it.
+	third := copyMatch(fixture, "m3", start+7200, []string{"team_twelve"}, func(p *activision.PlayerGeneralStatsFromSpecificGame) {
+		if p.Player.Uno == fixturePlayer {
+			p.Player.Uno = "someone else"
+		}
+		if p.Player.Uno == fixtureMate1 {
+			p.Player.Username = "Majestic9"
+		}
+	})
+	// The matches order must not change the usernames.
+	matches := []activision.SpecificGameStatsResponse{second, third, first}
+
+	tests := []struct {
+		name       string
+		min        int
+		teammates  string
+		opponents  string
+		opponentsN int
+	}{
+		{
+			name:      "recurring opponents",
+			min:       2,
+			teammates: strings.Join([]string{fixtureMate2, fixtureMate1, fixtureMate3, fixtureRival1}, ","),
+			opponents: strings.Join([]string{fixtureRival4, fixtureRival3}, ","),
+		},
+		{
+			name:       "default minimum",
+			min:        0,
+			teammates:  strings.Join([]string{fixtureMate2, fixtureMate1, fixtureMate3, fixtureRival1}, ","),
+			opponents:  strings.Join([]string{fixtureRival4, fixtureRival3}, ","),
+			opponentsN: 2,
+		},
+		{
+			name:       "every opponent",
+			min:        1,
+			teammates:  strings.Join([]string{fixtureMate2, fixtureMate1, fixtureMate3, fixtureRival1}, ","),
+			opponentsN: len(fixture.Data.AllPlayers) - 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := MatchParticipants(matches, fixturePlayer, tt.min)
+			if report.Uno != fixturePlayer || report.Matches != 2 {
+				t.Fatalf("expected the player in 2 matches, got %d", report.Matches)
+			}
+			if got := unos(report.Teammates); got != tt.teammates {
+				t.Errorf("expected teammates %s, got %s", tt.teammates, got)
+			}
+			if tt.opponents != "" {
+				if got := unos(report.Opponents); !strings.HasPrefix(got, tt.opponents) {
+					t.Errorf("expected opponents %s, got %s", tt.opponents, got)
+				}
+			}
+			if tt.opponentsN != 0 && len(report.Opponents) != tt.opponentsN {
+				t.Errorf("expected %d opponents, got %d", tt.opponentsN, len(report.Opponents))
+			}
+			for _, p := range append(report.Teammates, report.Opponents...) {
+				if p.Uno == fixturePlayer || p.Uno == "" {
+					t.Errorf("unexpected participant %+v", p)
+				}
+				if len(p.MatchIDs) != p.Matches {
+					t.Errorf("participant %s has %d matches and %d match IDs", p.Uno, p.Matches, len(p.MatchIDs))
+				}
+			}
+		})
+	}
+
+	report := MatchParticipants(matches, fixturePlayer, 2)
+	// The teammates with the same number of matches are sorted by Uno ID, Majestic7 is the second.
+	mate := report.Teammates[1]
+	if mate.Username != "Majestic8" || mate.Matches != 2 || strings.Join(mate.MatchIDs, ",") != "m2,m1" {
+		t.Errorf("expected Majestic8 with the matches m2,m1, got %+v", mate)
+	}
+	if florezz := report.Teammates[3]; florezz.Matches != 1 {
+		t.Errorf("expected Florezz as teammate once, got %+v", florezz)
+	}
+}
@@ -0,0 +1,87 @@
+// This file is responsible for the xp progression of the player, the xp rate is calculated from the recent sessions
+// so the breaks between the sessions are not counted as playing time.
+
+package analytics
+
+import (
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// The xp sources of the matches.
+const (
+	XpSourceMatch     = "match"
+	XpSourceScore     = "score"
+	XpSourceMedal     = "medal"
+	XpSourceChallenge = "challenge"
+	XpSourceBonus     = "bonus"
+	XpSourceMisc      = "misc"
+)
+
+// XpSource is the xp that was earned from one source.
+type XpSource struct {
+	Source  string  `json:"source"`
+	Xp      float64 `json:"xp"`
+	Percent float64 `json:"percent"` // percent of the total xp
+}
+
+// Progression is the profile level of the player with the xp rate of the recent sessions and the projection to the next level,
+// the projections are 0 when the player did not earn xp in the recent games.
+type Progression struct {
+	Username         string        `json:"username"`
+	Level            float64       `json:"level"`
+	Prestige         float64       `json:"prestige"`
+	TotalXp          float64       `json:"totalXp"`
+	LevelXpRemainder float64       `json:"levelXpRemainder"`
+	Games            int           `json:"games"`
+	Sessions         int           `json:"sessions"`
+	PlayTime         time.Duration `json:"playTime"`
+	XpEarned         float64       `json:"xpEarned"`
+	XpPerHour        float64       `json:"xpPerHour"`
+	XpPerGame        float64       `json:"xpPerGame"`
+	Breakdown        []XpSource    `json:"breakdown"`
+	GamesToNextLevel float64       `json:"gamesToNextLevel"`
+	TimeToNextLevel  time.Duration `json:"timeToNextLevel"` // playing time, not including breaks
+}
+
+// BuildProgression calculate the progression of the player from his profile and his recent games, the games are split into
+// sessions with the given gap (DefaultSessionGap when gap <= 0) and the xp rate is the xp of the games divided by the sessions time.
+func BuildProgression(profile activision.LifetimeAndWeeklyResponse, recent activision.LastGamesResponse, gap time.Duration) Progression {
+	p := Progression{
+		Username:         profile.Data.Username,
+		Level:            profile.Data.Level,
+		Prestige:         profile.Data.Prestige,
+		TotalXp:          profile.Data.TotalXp,
+		LevelXpRemainder: profile.Data.LevelXpRemainder,
+		Games:            len(recent.Data.Matches),
+	}
+	sessions := SessionsReports(BuildSessions(recent, gap))
+	p.Sessions = len(sessions)
+	for _, s := range sessions {
+		p.PlayTime += s.TotalTime
+	}
+
+	sources := []XpSource{{Source: XpSourceMatch}, {Source: XpSourceScore}, {Source: XpSourceMedal},
+		{Source: XpSourceChallenge}, {Source: XpSourceBonus}, {Source: XpSourceMisc}}
+	for _, m := range recent.Data.Matches {
+		stats := m.PlayerStats
+		p.XpEarned += stats.TotalXp
+		for i, xp := range []float64{stats.MatchXp, stats.ScoreXp, stats.MedalXp, stats.ChallengeXp, stats.BonusXp, stats.MiscXp} {
+			sources[i].Xp += xp
+		}
+	}
+	for i := range sources {
+		sources[i].Percent = percent(sources[i].Xp, p.XpEarned)
+	}
+	p.Breakdown = sources
+
+	if p.XpEarned <= 0 || p.PlayTime <= 0 {
+		return p
+	}
+	p.XpPerHour = p.XpEarned / p.PlayTime.Hours()
+	p.XpPerGame = p.XpEarned / float64(p.Games)
+	p.GamesToNextLevel = p.LevelXpRemainder / p.XpPerGame
+	p.TimeToNextLevel = time.Duration(p.LevelXpRemainder / p.XpPerHour * float64(time.Hour)).Round(time.Minute)
+	return p
+}
@@ -0,0 +1,169 @@
+// This file is responsible for the data of the html report, the trends of every player, the placements histogram,
+// the mode and the map breakdowns and the top games of the player or the squad.
+
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// The default options of the report.
+const (
+	DefaultTrendWindow = 5
+	DefaultTopGames    = 10
+)
+
+// placementBuckets are the ranges of the placements histogram.
+var placementBuckets = []PlacementBucket{
+	{Label: "1", From: 1, To: 1},
+	{Label: "2-5", From: 2, To: 5},
+	{Label: "6-10", From: 6, To: 10},
+	{Label: "11-25", From: 11, To: 25},
+	{Label: "26-50", From: 26, To: 50},
+	{Label: "51+", From: 51, To: math.Inf(1)},
+}
+
+// ReportOptions are the options of BuildMatchesReport, zero values are replaced by the defaults.
+type ReportOptions struct {
+	TrendWindow int // the number of games of the rolling averages in the trends
+	TopGames    int
+}
+
+// PlayerTrend is the rolling averages of a single player from the oldest match to the newest.
+type PlayerTrend struct {
+	Player string         `json:"player"`
+	Points []RollingPoint `json:"points"`
+}
+
+// PlacementBucket is the number of games that ended with placement between From and To (including).
+type PlacementBucket struct {
+	Label string  `json:"label"`
+	From  float64 `json:"from"`
+	To    float64 `json:"-"` // +Inf for the last bucket, json can not hold it
+	Games int     `json:"games"`
+}
+
+// ModeStats is the performance in a single game mode, counted the same way as MapStats.
+type ModeStats struct {
+	Mode             string  `json:"mode"`
+	Games            int     `json:"games"`
+	Wins             int     `json:"wins"`
+	WinPercent       float64 `json:"winPercent"`
+	AveragePlacement float64 `json:"averagePlacement"`
+	Kills            float64 `json:"kills"`
+	Deaths           float64 `json:"deaths"`
+	Kd               float64 `json:"kd"`
+}
+
+// TopGame is one of the best games of the report by kills.
+type TopGame struct {
+	Player string           `json:"player"`
+	Match  activision.Match `json:"match"`
+}
+
+// MatchesReport is the data of the html report, the games, the wins and the placements are counted once for every match
+// and the kills, the deaths and the damage are the sum of all the players.
+type MatchesReport struct {
+	Players          []string          `json:"players"`
+	Games            int               `json:"games"`
+	Wins             int               `json:"wins"`
+	Kills            float64           `json:"kills"`
+	Deaths           float64           `json:"deaths"`
+	Kd               float64           `json:"kd"`
+	DamageDone       float64           `json:"damageDone"`
+	AveragePlacement float64           `json:"averagePlacement"`
+	TrendWindow      int               `json:"trendWindow"`
+	Trends           []PlayerTrend     `json:"trends"`
+	Placements       []PlacementBucket `json:"placements"`
+	Modes            []ModeStats       `json:"modes"`
+	Maps             []MapStats        `json:"maps"`
+	TopGames         []TopGame         `json:"topGames"`
+}
+
+// BuildMatchesReport build the report of the players, the players argument is the matches of each player by his name.
+func BuildMatchesReport(players map[string][]activision.Match, options ReportOptions) MatchesReport {
+	if options.TrendWindow <= 0 {
+		options.TrendWindow = DefaultTrendWindow
+	}
+	if options.TopGames <= 0 {
+		options.TopGames = DefaultTopGames
+	}
+	report := MatchesReport{TrendWindow: options.TrendWindow, Maps: SquadMatchesByMap(players)}
+	for name := range players {
+		report.Players = append(report.Players, name)
+	}
+	sort.Strings(report.Players)
+
+	report.Placements = make([]PlacementBucket, len(placementBuckets))
+	copy(report.Placements, placementBuckets)
+	modes := make(map[string]*ModeStats)
+	modePlacements := make(map[string]float64)
+	seen := make(map[string]bool)
+	var placements float64
+	for _, name := range report.Players {
+		matches := players[name]
+		report.Trends = append(report.Trends, PlayerTrend{Player: name, Points: RollingSeries(matches, options.TrendWindow)})
+		for _, m := range matches {
+			report.TopGames = append(report.TopGames, TopGame{Player: name, Match: m})
+			report.Kills += m.PlayerStats.Kills
+			report.Deaths += m.PlayerStats.Deaths
+			report.DamageDone += m.PlayerStats.DamageDone
+			mode, ok := modes[m.Mode]
+			if !ok {
+				mode = &ModeStats{Mode: m.Mode}
+				modes[m.Mode] = mode
+			}
+			mode.Kills += m.PlayerStats.Kills
+			mode.Deaths += m.PlayerStats.Deaths
+			if seen[m.MatchID] {
+				continue
+			}
+			seen[m.MatchID] = true
+			placement := m.PlayerStats.TeamPlacement
+			report.Games++
+			mode.Games++
+			placements += placement
+			modePlacements[m.Mode] += placement
+			if placement == 1 {
+				report.Wins++
+				mode.Wins++
+			}
+			for i, bucket := range report.Placements {
+				if placement >= bucket.From && placement <= bucket.To {
+					report.Placements[i].Games++
+					break
+				}
+			}
+		}
+	}
+	report.Kd = ratio(report.Kills, report.Deaths)
+	report.AveragePlacement = ratio(placements, float64(report.Games))
+
+	for code, mode := range modes {
+		mode.WinPercent = percent(float64(mode.Wins), float64(mode.Games))
+		mode.AveragePlacement = ratio(modePlacements[code], float64(mode.Games))
+		mode.Kd = ratio(mode.Kills, mode.Deaths)
+		report.Modes = append(report.Modes, *mode)
+	}
+	sort.Slice(report.Modes, func(i, j int) bool {
+		if report.Modes[i].Games != report.Modes[j].Games {
+			return report.Modes[i].Games > report.Modes[j].Games
+		}
+		return report.Modes[i].Mode < report.Modes[j].Mode
+	})
+
+	sort.SliceStable(report.TopGames, func(i, j int) bool {
+		a, b := report.TopGames[i].Match.PlayerStats, report.TopGames[j].Match.PlayerStats
+		if a.Kills != b.Kills {
+			return a.Kills > b.Kills
+		}
+		return a.DamageDone > b.DamageDone
+	})
+	if len(report.TopGames) > options.TopGames {
+		report.TopGames = report.TopGames[:options.TopGames]
+	}
+	return report
+}
@@ -0,0 +1,170 @@
+// This file contains the rolling averages and the consistency metrics that we calculate over the player match history,
+// all the functions work on the matches that we already have so there is no need for another request to the official API.
+
+package analytics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// RollingAverages holds the averages of the last N games of the player.
+type RollingAverages struct {
+	Games      int     `json:"games"`
+	KdRatio    float64 `json:"kdRatio"`
+	Kills      float64 `json:"kills"`
+	Deaths     float64 `json:"deaths"`
+	DamageDone float64 `json:"damageDone"`
+}
+
+// RollingPoint is the rolling averages that was correct right after the match with the given MatchID.
+type RollingPoint struct {
+	MatchID         string          `json:"matchID"`
+	UtcStartSeconds float64         `json:"utcStartSeconds"`
+	Averages        RollingAverages `json:"averages"`
+}
+
+// MetricConsistency describe how much a metric changes from game to game.
+type MetricConsistency struct {
+	Mean              float64 `json:"mean"`
+	Median            float64 `json:"median"`
+	StandardDeviation float64 `json:"standardDeviation"`
+	Min               float64 `json:"min"`
+	Max               float64 `json:"max"`
+}
+
+// Consistency holds the consistency metrics for the main stats of the player.
+type Consistency struct {
+	Games         int               `json:"games"`
+	Kills         MetricConsistency `json:"kills"`
+	KdRatio       MetricConsistency `json:"kdRatio"`
+	DamageDone    MetricConsistency `json:"damageDone"`
+	TeamPlacement MetricConsistency `json:"teamPlacement"`
+}
+
+// LastNAverages return the averages of the most recent n games, in case n <= 0 or bigger then the number of matches
+// we will use all the matches that we have.
+// The KD here is the total kills divided by the total deaths and not the average of the games KD ratios,
+// that way a single game with 0 deaths will not ruin the result.
+func LastNAverages(matches []activision.Match, n int) RollingAverages {
+	recent := newestFirst(matches)
+	if n > 0 && n < len(recent) {
+		recent = recent[:n]
+	}
+	return averages(recent)
+}
+
+// RollingSeries return the rolling averages over window of n games for every match from the oldest to the newest,
+// the first points will contain less then n games until we have enough history.
+func RollingSeries(matches []activision.Match, n int) []RollingPoint {
+	if n <= 0 {
+		n = 1
+	}
+	ordered := oldestFirst(matches)
+	series := make([]RollingPoint, 0, len(ordered))
+	for i, m := range ordered {
+		start := i - n + 1
+		if start < 0 {
+			start = 0
+		}
+		series = append(series, RollingPoint{MatchID: m.MatchID, UtcStartSeconds: m.UtcStartSeconds, Averages: averages(ordered[start : i+1])})
+	}
+	return series
+}
+
+// MatchesConsistency calculate the mean, median and standard deviation of the main stats over all the given matches.
+func MatchesConsistency(matches []activision.Match) Consistency {
+	kills := make([]float64, 0, len(matches))
+	kds := make([]float64, 0, len(matches))
+	damage := make([]float64, 0, len(matches))
+	placements := make([]float64, 0, len(matches))
+	for _, m := range matches {
+		kills = append(kills, m.PlayerStats.Kills)
+		kds = append(kds, matchKd(m.PlayerStats))
+		damage = append(damage, m.PlayerStats.DamageDone)
+		placements = append(placements, m.PlayerStats.TeamPlacement)
+	}
+	return Consistency{
+		Games:         len(matches),
+		Kills:         metricConsistency(kills),
+		KdRatio:       metricConsistency(kds),
+		DamageDone:    metricConsistency(damage),
+		TeamPlacement: metricConsistency(placements),
+	}
+}
+
+/***************************************** Help functions *****************************************/
+
+func averages(matches []activision.Match) RollingAverages {
+	result := RollingAverages{Games: len(matches)}
+	if len(matches) == 0 {
+		return result
+	}
+	var kills, deaths, damage float64
+	for _, m := range matches {
+		kills += m.PlayerStats.Kills
+		deaths += m.PlayerStats.Deaths
+		damage += m.PlayerStats.DamageDone
+	}
+	games := float64(len(matches))
+	result.Kills = kills / games
+	result.Deaths = deaths / games
+	result.DamageDone = damage / games
+	result.KdRatio = ratio(kills, deaths)
+	return result
+}
+
+// ratio is the way that activision calculate KD, when there is no deaths the KD is the number of kills.
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return a
+	}
+	return a / b
+}
+
+func matchKd(s activision.PlayerStatsFromMatch) float64 {
+	return ratio(s.Kills, s.Deaths)
+}
+
+func metricConsistency(values []float64) MetricConsistency {
+	var result MetricConsistency
+	if len(values) == 0 {
+		return result
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	result.Min = sorted[0]
+	result.Max = sorted[len(sorted)-1]
+	if len(sorted)%2 == 1 {
+		result.Median = sorted[len(sorted)/2]
+	} else {
+		result.Median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	result.Mean = sum / float64(len(sorted))
+	var variance float64
+	for _, v := range sorted {
+		variance += (v - result.Mean) * (v - result.Mean)
+	}
+	result.StandardDeviation = math.Sqrt(variance / float64(len(sorted)))
+	return result
+}
+
+// newestFirst return a sorted copy of the matches, the official API already return the matches from the newest game
+// but after we concat responses (like in GetLastGamesStatsByCycles) or merge squad matches we can't count on that.
+func newestFirst(matches []activision.Match) []activision.Match {
+	sorted := append([]activision.Match(nil), matches...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].UtcStartSeconds > sorted[j].UtcStartSeconds })
+	return sorted
+}
+
+func oldestFirst(matches []activision.Match) []activision.Match {
+	sorted := append([]activision.Match(nil), matches...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].UtcStartSeconds < sorted[j].UtcStartSeconds })
+	return sorted
+}
@@ -0,0 +1,119 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// statsMatch return a match that started at the given second with the kills, deaths and damage.
+func statsMatch(id string, start, kills, deaths, damage float64) activision.Match {
+	return activision.Match{MatchID: id, UtcStartSeconds: start,
+		PlayerStats: activision.PlayerStatsFromMatch{Kills: kills, Deaths: deaths, DamageDone: damage}}
+}
+
+func TestLastNAverages(t *testing.T) {
+	// The matches are not sorted, the newest is "4".
+	matches := []activision.Match{
+		statsMatch("2", 200, 4, 2, 1000),
+		statsMatch("4", 400, 8, 0, 3000),
+		statsMatch("1", 100, 0, 4, 200),
+		statsMatch("3", 300, 2, 2, 800),
+	}
+	tests := []struct {
+		name string
+		n    int
+		want RollingAverages
+	}{
+		{name: "last game", n: 1, want: RollingAverages{Games: 1, KdRatio: 8, Kills: 8, DamageDone: 3000}},
+		{name: "last two games", n: 2, want: RollingAverages{Games: 2, KdRatio: 5, Kills: 5, Deaths: 1, DamageDone: 1900}},
+		{name: "window longer than the matches", n: 10, want: RollingAverages{Games: 4, KdRatio: 14.0 / 8, Kills: 3.5, Deaths: 2, DamageDone: 1250}},
+		{name: "zero window use all the matches", n: 0, want: RollingAverages{Games: 4, KdRatio: 14.0 / 8, Kills: 3.5, Deaths: 2, DamageDone: 1250}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LastNAverages(matches, tt.n); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+	if got := LastNAverages(nil, 5); got != (RollingAverages{}) {
+		t.Errorf("expected empty averages without matches, got %+v", got)
+	}
+}
+
+func TestRollingSeries(t *testing.T) {
+	matches := []activision.Match{
+		statsMatch("3", 300, 6, 2, 0),
+		statsMatch("1", 100, 2, 1, 0),
+		statsMatch("2", 200, 4, 1, 0),
+	}
+	tests := []struct {
+		name  string
+		n     int
+		games []int
+		kills []float64
+	}{
+		{name: "window shorter than the history", n: 2, games: []int{1, 2, 2}, kills: []float64{2, 3, 5}},
+		{name: "window longer than the history", n: 5, games: []int{1, 2, 3}, kills: []float64{2, 3, 4}},
+		{name: "zero window is a single game", n: 0, games: []int{1, 1, 1}, kills: []float64{2, 4, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := RollingSeries(matches, tt.n)
+			if len(series) != len(matches) {
+				t.Fatalf("expected %d points, got %d", len(matches), len(series))
+			}
+			for i, point := range series {
+				// The points are from the oldest match to the newest.
+				if want := string(rune('1' + i)); point.MatchID != want {
+					t.Errorf("point %d: expected match %s, got %s", i, want, point.MatchID)
+				}
+				if point.Averages.Games != tt.games[i] || point.Averages.Kills != tt.kills[i] {
+					t.Errorf("point %d: expected %d games and %v kills, got %+v", i, tt.games[i], tt.kills[i], point.Averages)
+				}
+			}
+		})
+	}
+}
+
+func TestMetricConsistency(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   MetricConsistency
+	}{
+		{name: "empty", values: nil, want: MetricConsistency{}},
+		{name: "single value", values: []float64{7}, want: MetricConsistency{Mean: 7, Median: 7, Min: 7, Max: 7}},
+		{name: "odd length", values: []float64{9, 1, 5}, want: MetricConsistency{Mean: 5, Median: 5, StandardDeviation: math.Sqrt(32.0 / 3), Min: 1, Max: 9}},
+		{name: "even length median is the average of the middle values", values: []float64{8, 2, 4, 6}, want: MetricConsistency{Mean: 5, Median: 5, StandardDeviation: math.Sqrt(5), Min: 2, Max: 8}},
+		{name: "even length with equal middle values", values: []float64{3, 1, 3, 10}, want: MetricConsistency{Mean: 4.25, Median: 3, StandardDeviation: math.Sqrt(11.6875), Min: 1, Max: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := metricConsistency(tt.values)
+			if math.Abs(got.StandardDeviation-tt.want.StandardDeviation) > 1e-9 {
+				t.Errorf("expected standard deviation %v, got %v", tt.want.StandardDeviation, got.StandardDeviation)
+			}
+			got.StandardDeviation = tt.want.StandardDeviation
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchesConsistency(t *testing.T) {
+	matches := []activision.Match{statsMatch("1", 100, 2, 0, 100), statsMatch("2", 200, 4, 2, 300)}
+	matches[0].PlayerStats.TeamPlacement = 1
+	matches[1].PlayerStats.TeamPlacement = 20
+	got := MatchesConsistency(matches)
+	if got.Games != 2 {
+		t.Errorf("expected 2 games, got %d", got.Games)
+	}
+	// The KD of the game without deaths is its kills.
+	if got.KdRatio.Median != 2 || got.Kills.Median != 3 || got.DamageDone.Median != 200 || got.TeamPlacement.Median != 10.5 {
+		t.Errorf("unexpected medians %+v", got)
+	}
+}
@@ -0,0 +1,228 @@
+// Package analytics contains calculations that we run on top of the data that we already received from the activision API,
+// nothing here send requests, all the functions receive the domain objects and return new report objects.
+
+// This file is responsible for splitting the flat matches array into playing sessions, a session is a group of matches
+// that the player (or the squad) played one after the other without a long break between them.
+
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// DefaultSessionGap is the break length that we use in order to decide that a new session started,
+// if the player rested more then this time between the end of one match and the start of the next we will open new session.
+const DefaultSessionGap = 45 * time.Minute
+
+// SessionGame is a single match inside a session, because a session can be built for a squad we keep the stats
+// of every player from the squad that played in this match under his username.
+type SessionGame struct {
+	MatchID         string                                     `json:"matchID"`
+	Mode            string                                     `json:"mode"`
+	UtcStartSeconds float64                                    `json:"utcStartSeconds"`
+	UtcEndSeconds   float64                                    `json:"utcEndSeconds"`
+	TeamPlacement   float64                                    `json:"teamPlacement"`
+	Players         map[string]activision.PlayerStatsFromMatch `json:"players"`
+	Awards          map[string]activision.Awards               `json:"awards"`
+}
+
+// Session holds the games that was played in a row, the games are sorted from the oldest to the newest.
+type Session struct {
+	Games []SessionGame `json:"games"`
+}
+
+// SessionReport is the summary that we produce for each session.
+type SessionReport struct {
+	Start            time.Time             `json:"start"`
+	End              time.Time             `json:"end"`
+	Games            int                   `json:"games"`
+	Wins             int                   `json:"wins"`
+	Kills            float64               `json:"kills"`
+	Deaths           float64               `json:"deaths"`
+	DamageDone       float64               `json:"damageDone"`
+	AveragePlacement float64               `json:"averagePlacement"`
+	TotalTime        time.Duration         `json:"totalTime"` // the time that the players played in the games, not including the lobbies and the breaks
+	BestGame         SessionGame           `json:"bestGame"`
+	Awards           AwardsSummary         `json:"awards"` // the awards of all the players, Matches is the number of the players games
+	Players          []SessionPlayerReport `json:"players"`
+}
+
+// SessionPlayerReport is the part of the session that belongs to one player, for a single player session we will have only one of those.
+type SessionPlayerReport struct {
+	Username   string  `json:"username"`
+	Games      int     `json:"games"`
+	Kills      float64 `json:"kills"`
+	Deaths     float64 `json:"deaths"`
+	DamageDone float64 `json:"damageDone"`
+	Awards     int     `json:"awards"`
+}
+
+/***************************************** Functions for building the sessions *****************************************/
+
+// BuildSessions split the matches of a single player into sessions according to the gap argument,
+// in case gap <= 0 we will use the DefaultSessionGap.
+func BuildSessions(r activision.LastGamesResponse, gap time.Duration) []Session {
+	return BuildSquadSessions([]activision.LastGamesResponse{r}, gap)
+}
+
+// BuildSquadSessions receive the last games responses of all the squad members and build the sessions of the squad,
+// matches with the same MatchID are merged into one SessionGame so a game that the whole squad played together will be counted once.
+func BuildSquadSessions(responses []activision.LastGamesResponse, gap time.Duration) []Session {
+	if gap <= 0 {
+		gap = DefaultSessionGap
+	}
+	games := mergeSquadGames(responses)
+	var sessions []Session
+	for _, g := range games {
+		// A new session is opened for the first game and for every game that started more then 'gap' after the previous game ended.
+		if len(sessions) == 0 || g.UtcStartSeconds-gameEnd(lastGame(sessions)) > gap.Seconds() {
+			sessions = append(sessions, Session{})
+		}
+		current := &sessions[len(sessions)-1]
+		current.Games = append(current.Games, g)
+	}
+	return sessions
+}
+
+// mergeSquadGames create one SessionGame for each MatchID from all the responses and return them sorted by the start time.
+func mergeSquadGames(responses []activision.LastGamesResponse) []SessionGame {
+	byID := make(map[string]*SessionGame)
+	var order []string
+	for _, r := range responses {
+		for _, m := range r.Data.Matches {
+			g, ok := byID[m.MatchID]
+			if !ok {
+				g = &SessionGame{
+					MatchID:         m.MatchID,
+					Mode:            m.Mode,
+					UtcStartSeconds: m.UtcStartSeconds,
+					UtcEndSeconds:   m.UtcEndSeconds,
+					TeamPlacement:   m.PlayerStats.TeamPlacement,
+					Players:         make(map[string]activision.PlayerStatsFromMatch),
+					Awards:          make(map[string]activision.Awards),
+				}
+				byID[m.MatchID] = g
+				order = append(order, m.MatchID)
+			}
+			g.Players[r.Username] = m.PlayerStats
+			g.Awards[r.Username] = m.Player.Awards
+		}
+	}
+	games := make([]SessionGame, 0, len(order))
+	for _, id := range order {
+		games = append(games, *byID[id])
+	}
+	sort.SliceStable(games, func(i, j int) bool { return games[i].UtcStartSeconds < games[j].UtcStartSeconds })
+	return games
+}
+
+func lastGame(sessions []Session) SessionGame {
+	s := sessions[len(sessions)-1]
+	return s.Games[len(s.Games)-1]
+}
+
+// gameEnd return the end time of the game in seconds, old responses does not contain the utcEndSeconds field
+// so in that case we will use the longest time played of the players that we have.
+func gameEnd(g SessionGame) float64 {
+	if g.UtcEndSeconds > 0 {
+		return g.UtcEndSeconds
+	}
+	end := g.UtcStartSeconds
+	for _, p := range g.Players {
+		if g.UtcStartSeconds+p.TimePlayed > end {
+			end = g.UtcStartSeconds + p.TimePlayed
+		}
+	}
+	return end
+}
+
+// gamePlayTime return the time in seconds that the squad played in the game, which is the longest time played of the players
+// because a player that was eliminated stop playing before the match ends. In case none of the players has time played we use
+// the length of the match.
+func gamePlayTime(g SessionGame) float64 {
+	played := 0.0
+	for _, p := range g.Players {
+		if p.TimePlayed > played {
+			played = p.TimePlayed
+		}
+	}
+	if played > 0 {
+		return played
+	}
+	return gameEnd(g) - g.UtcStartSeconds
+}
+
+/***************************************** Functions for the session reports *****************************************/
+
+// Report summarize the session games, the kills and the damage are the sum of all the players that played in the session.
+func (s Session) Report() SessionReport {
+	var report SessionReport
+	if len(s.Games) == 0 {
+		return report
+	}
+	report.Start = secondsToTime(s.Games[0].UtcStartSeconds)
+	report.End = secondsToTime(gameEnd(s.Games[len(s.Games)-1]))
+	report.Games = len(s.Games)
+
+	players := make(map[string]*SessionPlayerReport)
+	var usernames []string
+	var placements float64
+	var awards []activision.Awards
+	bestKills := -1.0
+	for _, g := range s.Games {
+		if g.TeamPlacement == 1 {
+			report.Wins++
+		}
+		placements += g.TeamPlacement
+		report.TotalTime += time.Duration(gamePlayTime(g) * float64(time.Second))
+
+		gameKills := 0.0
+		for username, stats := range g.Players {
+			p, ok := players[username]
+			if !ok {
+				p = &SessionPlayerReport{Username: username}
+				players[username] = p
+				usernames = append(usernames, username)
+			}
+			p.Games++
+			p.Kills += stats.Kills
+			p.Deaths += stats.Deaths
+			p.DamageDone += stats.DamageDone
+			p.Awards += len(g.Awards[username])
+			awards = append(awards, g.Awards[username])
+			report.Kills += stats.Kills
+			report.Deaths += stats.Deaths
+			report.DamageDone += stats.DamageDone
+			gameKills += stats.Kills
+		}
+		// The best game is the one with the most kills, in case of a tie the better placement wins.
+		if gameKills > bestKills || (gameKills == bestKills && g.TeamPlacement < report.BestGame.TeamPlacement) {
+			bestKills = gameKills
+			report.BestGame = g
+		}
+	}
+	report.AveragePlacement = placements / float64(len(s.Games))
+	report.Awards = AggregateAwards(awards)
+
+	sort.Strings(usernames)
+	for _, username := range usernames {
+		report.Players = append(report.Players, *players[username])
+	}
+	return report
+}
+
+// SessionsReports return the report of each session in the same order of the sessions.
+func SessionsReports(sessions []Session) []SessionReport {
+	reports := make([]SessionReport, 0, len(sessions))
+	for _, s := range sessions {
+		reports = append(reports, s.Report())
+	}
+	return reports
+}
+
+func secondsToTime(seconds float64) time.Time {
+	return time.Unix(int64(seconds), 0).UTC()
+}
@@ -0,0 +1,37 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+func squadMatch(id string, start float64, timePlayed float64) activision.Match {
+	return activision.Match{
+		MatchID:         id,
+		UtcStartSeconds: start,
+		UtcEndSeconds:   start + 1800,
+		PlayerStats:     activision.PlayerStatsFromMatch{TimePlayed: timePlayed, TeamPlacement: 10},
+	}
+}
+
+// The total time of the session is the time that the squad played, the longest time played of the squad members in
+// every game, and not the length of the lobbies.
+func TestSessionTotalTimeUsesTimePlayed(t *testing.T) {
+	first := activision.LastGamesResponse{Username: "a", Data: activision.LastGamesResponseData{Matches: []activision.Match{
+		squadMatch("2", 2400, 300),
+		squadMatch("1", 0, 600),
+	}}}
+	second := activision.LastGamesResponse{Username: "b", Data: activision.LastGamesResponseData{Matches: []activision.Match{
+		squadMatch("2", 2400, 900),
+		squadMatch("1", 0, 200),
+	}}}
+	reports := SessionsReports(BuildSquadSessions([]activision.LastGamesResponse{first, second}, time.Hour))
+	if len(reports) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(reports))
+	}
+	if expected := 1500 * time.Second; reports[0].TotalTime != expected {
+		t.Errorf("expected total time %s, got %s", expected, reports[0].TotalTime)
+	}
+}
@@ -0,0 +1,93 @@
+// This file contains the streaks and the personal bests calculations over the player match history.
+
+package analytics
+
+import "github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+
+// Streaks holds the current and the longest streaks of wins and top 10 placements,
+// the current streak is counted from the most recent game backwards.
+type Streaks struct {
+	CurrentWinStreak    int `json:"currentWinStreak"`
+	LongestWinStreak    int `json:"longestWinStreak"`
+	CurrentTopTenStreak int `json:"currentTopTenStreak"`
+	LongestTopTenStreak int `json:"longestTopTenStreak"`
+}
+
+// PersonalBest is the best value that the player reached for a metric and the match that it happened in.
+type PersonalBest struct {
+	Value           float64 `json:"value"`
+	MatchID         string  `json:"matchID"`
+	UtcStartSeconds float64 `json:"utcStartSeconds"`
+}
+
+// PersonalBests holds the personal bests of the player from the given matches.
+type PersonalBests struct {
+	Kills          PersonalBest `json:"kills"`
+	DamageDone     PersonalBest `json:"damageDone"`
+	KdRatio        PersonalBest `json:"kdRatio"`
+	Headshots      PersonalBest `json:"headshots"`
+	ScorePerMinute PersonalBest `json:"scorePerMinute"`
+	TeamPlacement  PersonalBest `json:"teamPlacement"` // the lowest placement the player reached
+}
+
+// MatchesStreaks calculate the win (placement 1) and top 10 streaks from the team placement of each match,
+// games without a placement (for example plunder) are skipped and does not break the streak.
+func MatchesStreaks(matches []activision.Match) Streaks {
+	var result Streaks
+	currentWin, currentTopTen := 0, 0
+	for _, m := range oldestFirst(matches) {
+		placement := m.PlayerStats.TeamPlacement
+		if placement <= 0 {
+			continue
+		}
+		currentWin = nextStreak(currentWin, placement == 1)
+		currentTopTen = nextStreak(currentTopTen, placement <= 10)
+		result.LongestWinStreak = maxInt(result.LongestWinStreak, currentWin)
+		result.LongestTopTenStreak = maxInt(result.LongestTopTenStreak, currentTopTen)
+	}
+	result.CurrentWinStreak = currentWin
+	result.CurrentTopTenStreak = currentTopTen
+	return result
+}
+
+// MatchesPersonalBests scan the matches and return the best game for each metric,
+// in case of a tie we keep the older game because that is when the record was set.
+func MatchesPersonalBests(matches []activision.Match) PersonalBests {
+	var result PersonalBests
+	for _, m := range oldestFirst(matches) {
+		s := m.PlayerStats
+		updateBest(&result.Kills, s.Kills, m, false)
+		updateBest(&result.DamageDone, s.DamageDone, m, false)
+		updateBest(&result.KdRatio, matchKd(s), m, false)
+		updateBest(&result.Headshots, s.Headshots, m, false)
+		updateBest(&result.ScorePerMinute, s.ScorePerMinute, m, false)
+		if s.TeamPlacement > 0 {
+			updateBest(&result.TeamPlacement, s.TeamPlacement, m, true)
+		}
+	}
+	return result
+}
+
+func updateBest(best *PersonalBest, value float64, m activision.Match, lowerIsBetter bool) {
+	better := value > best.Value
+	if lowerIsBetter {
+		better = value < best.Value
+	}
+	if best.MatchID == "" || better {
+		*best = PersonalBest{Value: value, MatchID: m.MatchID, UtcStartSeconds: m.UtcStartSeconds}
+	}
+}
+
+func nextStreak(current int, ok bool) int {
+	if ok {
+		return current + 1
+	}
+	return 0
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// placementsMatches return matches with the placements from the oldest to the newest.
+func placementsMatches(placements ...float64) []activision.Match {
+	matches := make([]activision.Match, 0, len(placements))
+	for i, p := range placements {
+		matches = append(matches, activision.Match{MatchID: string(rune('a' + i)), UtcStartSeconds: float64(100 + i),
+			PlayerStats: activision.PlayerStatsFromMatch{TeamPlacement: p}})
+	}
+	return matches
+}
+
+func TestMatchesStreaks(t *testing.T) {
+	tests := []struct {
+		name       string
+		placements []float64
+		want       Streaks
+	}{
+		{name: "no matches", want: Streaks{}},
+		{name: "current is also the longest", placements: []float64{30, 1, 1}, want: Streaks{CurrentWinStreak: 2, LongestWinStreak: 2, CurrentTopTenStreak: 2, LongestTopTenStreak: 2}},
+		{name: "longest streak in the past", placements: []float64{1, 1, 1, 5, 1}, want: Streaks{CurrentWinStreak: 1, LongestWinStreak: 3, CurrentTopTenStreak: 5, LongestTopTenStreak: 5}},
+		{name: "streak broken by the last game", placements: []float64{2, 1, 1, 40}, want: Streaks{LongestWinStreak: 2, LongestTopTenStreak: 3}},
+		{name: "games without placement are skipped", placements: []float64{1, 0, 1, -1, 1}, want: Streaks{CurrentWinStreak: 3, LongestWinStreak: 3, CurrentTopTenStreak: 3, LongestTopTenStreak: 3}},
+		{name: "top ten without wins", placements: []float64{10, 11, 3, 7}, want: Streaks{CurrentTopTenStreak: 2, LongestTopTenStreak: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := placementsMatches(tt.placements...)
+			// The order of the argument must not matter.
+			for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+				matches[i], matches[j] = matches[j], matches[i]
+			}
+			if got := MatchesStreaks(matches); got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchesPersonalBests(t *testing.T) {
+	matches := placementsMatches(5, 0, 2, 2)
+	matches[0].PlayerStats.Kills, matches[0].PlayerStats.Deaths, matches[0].PlayerStats.DamageDone = 10, 5, 2500
+	matches[1].PlayerStats.Kills, matches[1].PlayerStats.Deaths, matches[1].PlayerStats.Headshots = 6, 0, 4
+	matches[2].PlayerStats.Kills, matches[2].PlayerStats.Deaths, matches[2].PlayerStats.DamageDone = 10, 1, 4000
+	matches[3].PlayerStats.ScorePerMinute = 350
+
+	got := MatchesPersonalBests(matches)
+	tests := []struct {
+		name  string
+		best  PersonalBest
+		value float64
+		match string
+	}{
+		// The tie of the kills is kept by the older game.
+		{name: "kills", best: got.Kills, value: 10, match: "a"},
+		{name: "damage", best: got.DamageDone, value: 4000, match: "c"},
+		{name: "kd", best: got.KdRatio, value: 10, match: "c"},
+		{name: "headshots", best: got.Headshots, value: 4, match: "b"},
+		{name: "score per minute", best: got.ScorePerMinute, value: 350, match: "d"},
+		// The lowest placement, the game without placement is ignored.
+		{name: "placement", best: got.TeamPlacement, value: 2, match: "c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.best.Value != tt.value || tt.best.MatchID != tt.match {
+				t.Errorf("expected %v in match %s, got %v in match %s", tt.value, tt.match, tt.best.Value, tt.best.MatchID)
+			}
+		})
+	}
+
+	if got := MatchesPersonalBests(placementsMatches(0, 0)); got.TeamPlacement.MatchID != "" {
+		t.Errorf("expected no placement best without placements, got %+v", got.TeamPlacement)
+	}
+}
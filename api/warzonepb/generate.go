@@ -0,0 +1,5 @@
+// Package warzonepb contains the protobuf messages and the gRPC service of WarzoneSquad, the code is generated from warzone.proto
+// with protoc-gen-go and protoc-gen-go-grpc, after changing the proto file run 'go generate ./api/warzonepb'.
+package warzonepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative warzone.proto
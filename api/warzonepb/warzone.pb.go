@@ -0,0 +1,1748 @@
+// The gRPC API of WarzoneSquad, it expose the same data as the http server for services that work only with gRPC.
+// The Go code in this directory is generated from this file, see generate.go.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: warzone.proto
+
+package warzonepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Player struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// psn, xbl, battle or uno.
+	Platform      string `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	mi := &file_warzone_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Player) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Player) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+type PlayerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kills         float64                `protobuf:"fixed64,1,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths        float64                `protobuf:"fixed64,2,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	KdRatio       float64                `protobuf:"fixed64,3,opt,name=kd_ratio,json=kdRatio,proto3" json:"kd_ratio,omitempty"`
+	Assists       float64                `protobuf:"fixed64,4,opt,name=assists,proto3" json:"assists,omitempty"`
+	Headshots     float64                `protobuf:"fixed64,5,opt,name=headshots,proto3" json:"headshots,omitempty"`
+	DamageDone    float64                `protobuf:"fixed64,6,opt,name=damage_done,json=damageDone,proto3" json:"damage_done,omitempty"`
+	DamageTaken   float64                `protobuf:"fixed64,7,opt,name=damage_taken,json=damageTaken,proto3" json:"damage_taken,omitempty"`
+	Score         float64                `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"`
+	GulagKills    float64                `protobuf:"fixed64,9,opt,name=gulag_kills,json=gulagKills,proto3" json:"gulag_kills,omitempty"`
+	GulagDeaths   float64                `protobuf:"fixed64,10,opt,name=gulag_deaths,json=gulagDeaths,proto3" json:"gulag_deaths,omitempty"`
+	TeamPlacement float64                `protobuf:"fixed64,11,opt,name=team_placement,json=teamPlacement,proto3" json:"team_placement,omitempty"`
+	// In seconds.
+	TimePlayed    float64 `protobuf:"fixed64,12,opt,name=time_played,json=timePlayed,proto3" json:"time_played,omitempty"`
+	TotalXp       float64 `protobuf:"fixed64,13,opt,name=total_xp,json=totalXp,proto3" json:"total_xp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerStats) Reset() {
+	*x = PlayerStats{}
+	mi := &file_warzone_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStats) ProtoMessage() {}
+
+func (x *PlayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStats.ProtoReflect.Descriptor instead.
+func (*PlayerStats) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlayerStats) GetKills() float64 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetDeaths() float64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetKdRatio() float64 {
+	if x != nil {
+		return x.KdRatio
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetAssists() float64 {
+	if x != nil {
+		return x.Assists
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetHeadshots() float64 {
+	if x != nil {
+		return x.Headshots
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetDamageDone() float64 {
+	if x != nil {
+		return x.DamageDone
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetDamageTaken() float64 {
+	if x != nil {
+		return x.DamageTaken
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetGulagKills() float64 {
+	if x != nil {
+		return x.GulagKills
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetGulagDeaths() float64 {
+	if x != nil {
+		return x.GulagDeaths
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetTeamPlacement() float64 {
+	if x != nil {
+		return x.TeamPlacement
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetTimePlayed() float64 {
+	if x != nil {
+		return x.TimePlayed
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetTotalXp() float64 {
+	if x != nil {
+		return x.TotalXp
+	}
+	return 0
+}
+
+type Match struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MatchId         string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Mode            string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Map             string                 `protobuf:"bytes,3,opt,name=map,proto3" json:"map,omitempty"`
+	MapName         string                 `protobuf:"bytes,4,opt,name=map_name,json=mapName,proto3" json:"map_name,omitempty"`
+	UtcStartSeconds int64                  `protobuf:"varint,5,opt,name=utc_start_seconds,json=utcStartSeconds,proto3" json:"utc_start_seconds,omitempty"`
+	UtcEndSeconds   int64                  `protobuf:"varint,6,opt,name=utc_end_seconds,json=utcEndSeconds,proto3" json:"utc_end_seconds,omitempty"`
+	Team            string                 `protobuf:"bytes,7,opt,name=team,proto3" json:"team,omitempty"`
+	Stats           *PlayerStats           `protobuf:"bytes,8,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_warzone_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Match) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Match) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Match) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+func (x *Match) GetMapName() string {
+	if x != nil {
+		return x.MapName
+	}
+	return ""
+}
+
+func (x *Match) GetUtcStartSeconds() int64 {
+	if x != nil {
+		return x.UtcStartSeconds
+	}
+	return 0
+}
+
+func (x *Match) GetUtcEndSeconds() int64 {
+	if x != nil {
+		return x.UtcEndSeconds
+	}
+	return 0
+}
+
+func (x *Match) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *Match) GetStats() *PlayerStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetRecentMatchesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Player *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	// 20 when not set.
+	Games         int32 `protobuf:"varint,2,opt,name=games,proto3" json:"games,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentMatchesRequest) Reset() {
+	*x = GetRecentMatchesRequest{}
+	mi := &file_warzone_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentMatchesRequest) ProtoMessage() {}
+
+func (x *GetRecentMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentMatchesRequest.ProtoReflect.Descriptor instead.
+func (*GetRecentMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRecentMatchesRequest) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *GetRecentMatchesRequest) GetGames() int32 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+type GetRecentMatchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Matches       []*Match               `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentMatchesResponse) Reset() {
+	*x = GetRecentMatchesResponse{}
+	mi := &file_warzone_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentMatchesResponse) ProtoMessage() {}
+
+func (x *GetRecentMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentMatchesResponse.ProtoReflect.Descriptor instead.
+func (*GetRecentMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRecentMatchesResponse) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *GetRecentMatchesResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type LifetimeStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Wins           float64                `protobuf:"fixed64,1,opt,name=wins,proto3" json:"wins,omitempty"`
+	Kills          float64                `protobuf:"fixed64,2,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths         float64                `protobuf:"fixed64,3,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	KdRatio        float64                `protobuf:"fixed64,4,opt,name=kd_ratio,json=kdRatio,proto3" json:"kd_ratio,omitempty"`
+	Downs          float64                `protobuf:"fixed64,5,opt,name=downs,proto3" json:"downs,omitempty"`
+	TopFive        float64                `protobuf:"fixed64,6,opt,name=top_five,json=topFive,proto3" json:"top_five,omitempty"`
+	TopTen         float64                `protobuf:"fixed64,7,opt,name=top_ten,json=topTen,proto3" json:"top_ten,omitempty"`
+	TopTwentyFive  float64                `protobuf:"fixed64,8,opt,name=top_twenty_five,json=topTwentyFive,proto3" json:"top_twenty_five,omitempty"`
+	Revives        float64                `protobuf:"fixed64,9,opt,name=revives,proto3" json:"revives,omitempty"`
+	GamesPlayed    float64                `protobuf:"fixed64,10,opt,name=games_played,json=gamesPlayed,proto3" json:"games_played,omitempty"`
+	ScorePerMinute float64                `protobuf:"fixed64,11,opt,name=score_per_minute,json=scorePerMinute,proto3" json:"score_per_minute,omitempty"`
+	// In seconds.
+	TimePlayed    float64 `protobuf:"fixed64,12,opt,name=time_played,json=timePlayed,proto3" json:"time_played,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LifetimeStats) Reset() {
+	*x = LifetimeStats{}
+	mi := &file_warzone_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LifetimeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LifetimeStats) ProtoMessage() {}
+
+func (x *LifetimeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LifetimeStats.ProtoReflect.Descriptor instead.
+func (*LifetimeStats) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LifetimeStats) GetWins() float64 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetKills() float64 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetDeaths() float64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetKdRatio() float64 {
+	if x != nil {
+		return x.KdRatio
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetDowns() float64 {
+	if x != nil {
+		return x.Downs
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetTopFive() float64 {
+	if x != nil {
+		return x.TopFive
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetTopTen() float64 {
+	if x != nil {
+		return x.TopTen
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetTopTwentyFive() float64 {
+	if x != nil {
+		return x.TopTwentyFive
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetRevives() float64 {
+	if x != nil {
+		return x.Revives
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetGamesPlayed() float64 {
+	if x != nil {
+		return x.GamesPlayed
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetScorePerMinute() float64 {
+	if x != nil {
+		return x.ScorePerMinute
+	}
+	return 0
+}
+
+func (x *LifetimeStats) GetTimePlayed() float64 {
+	if x != nil {
+		return x.TimePlayed
+	}
+	return 0
+}
+
+type WeeklyStats struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Kills              float64                `protobuf:"fixed64,1,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths             float64                `protobuf:"fixed64,2,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	KdRatio            float64                `protobuf:"fixed64,3,opt,name=kd_ratio,json=kdRatio,proto3" json:"kd_ratio,omitempty"`
+	MatchesPlayed      float64                `protobuf:"fixed64,4,opt,name=matches_played,json=matchesPlayed,proto3" json:"matches_played,omitempty"`
+	DamageDone         float64                `protobuf:"fixed64,5,opt,name=damage_done,json=damageDone,proto3" json:"damage_done,omitempty"`
+	DamageTaken        float64                `protobuf:"fixed64,6,opt,name=damage_taken,json=damageTaken,proto3" json:"damage_taken,omitempty"`
+	GulagKills         float64                `protobuf:"fixed64,7,opt,name=gulag_kills,json=gulagKills,proto3" json:"gulag_kills,omitempty"`
+	GulagDeaths        float64                `protobuf:"fixed64,8,opt,name=gulag_deaths,json=gulagDeaths,proto3" json:"gulag_deaths,omitempty"`
+	HeadshotPercentage float64                `protobuf:"fixed64,9,opt,name=headshot_percentage,json=headshotPercentage,proto3" json:"headshot_percentage,omitempty"`
+	ScorePerMinute     float64                `protobuf:"fixed64,10,opt,name=score_per_minute,json=scorePerMinute,proto3" json:"score_per_minute,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *WeeklyStats) Reset() {
+	*x = WeeklyStats{}
+	mi := &file_warzone_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeeklyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeeklyStats) ProtoMessage() {}
+
+func (x *WeeklyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeeklyStats.ProtoReflect.Descriptor instead.
+func (*WeeklyStats) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WeeklyStats) GetKills() float64 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetDeaths() float64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetKdRatio() float64 {
+	if x != nil {
+		return x.KdRatio
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetMatchesPlayed() float64 {
+	if x != nil {
+		return x.MatchesPlayed
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetDamageDone() float64 {
+	if x != nil {
+		return x.DamageDone
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetDamageTaken() float64 {
+	if x != nil {
+		return x.DamageTaken
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetGulagKills() float64 {
+	if x != nil {
+		return x.GulagKills
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetGulagDeaths() float64 {
+	if x != nil {
+		return x.GulagDeaths
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetHeadshotPercentage() float64 {
+	if x != nil {
+		return x.HeadshotPercentage
+	}
+	return 0
+}
+
+func (x *WeeklyStats) GetScorePerMinute() float64 {
+	if x != nil {
+		return x.ScorePerMinute
+	}
+	return 0
+}
+
+type GetLifetimeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLifetimeRequest) Reset() {
+	*x = GetLifetimeRequest{}
+	mi := &file_warzone_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLifetimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLifetimeRequest) ProtoMessage() {}
+
+func (x *GetLifetimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLifetimeRequest.ProtoReflect.Descriptor instead.
+func (*GetLifetimeRequest) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetLifetimeRequest) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type GetLifetimeResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Player           *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Level            float64                `protobuf:"fixed64,2,opt,name=level,proto3" json:"level,omitempty"`
+	Prestige         float64                `protobuf:"fixed64,3,opt,name=prestige,proto3" json:"prestige,omitempty"`
+	TotalXp          float64                `protobuf:"fixed64,4,opt,name=total_xp,json=totalXp,proto3" json:"total_xp,omitempty"`
+	LevelXpRemainder float64                `protobuf:"fixed64,5,opt,name=level_xp_remainder,json=levelXpRemainder,proto3" json:"level_xp_remainder,omitempty"`
+	Lifetime         *LifetimeStats         `protobuf:"bytes,6,opt,name=lifetime,proto3" json:"lifetime,omitempty"`
+	Weekly           *WeeklyStats           `protobuf:"bytes,7,opt,name=weekly,proto3" json:"weekly,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetLifetimeResponse) Reset() {
+	*x = GetLifetimeResponse{}
+	mi := &file_warzone_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLifetimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLifetimeResponse) ProtoMessage() {}
+
+func (x *GetLifetimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLifetimeResponse.ProtoReflect.Descriptor instead.
+func (*GetLifetimeResponse) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetLifetimeResponse) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *GetLifetimeResponse) GetLevel() float64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *GetLifetimeResponse) GetPrestige() float64 {
+	if x != nil {
+		return x.Prestige
+	}
+	return 0
+}
+
+func (x *GetLifetimeResponse) GetTotalXp() float64 {
+	if x != nil {
+		return x.TotalXp
+	}
+	return 0
+}
+
+func (x *GetLifetimeResponse) GetLevelXpRemainder() float64 {
+	if x != nil {
+		return x.LevelXpRemainder
+	}
+	return 0
+}
+
+func (x *GetLifetimeResponse) GetLifetime() *LifetimeStats {
+	if x != nil {
+		return x.Lifetime
+	}
+	return nil
+}
+
+func (x *GetLifetimeResponse) GetWeekly() *WeeklyStats {
+	if x != nil {
+		return x.Weekly
+	}
+	return nil
+}
+
+type GetMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchRequest) Reset() {
+	*x = GetMatchRequest{}
+	mi := &file_warzone_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchRequest) ProtoMessage() {}
+
+func (x *GetMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchRequest.ProtoReflect.Descriptor instead.
+func (*GetMatchRequest) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type MatchPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uno           string                 `protobuf:"bytes,1,opt,name=uno,proto3" json:"uno,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Stats         *PlayerStats           `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchPlayer) Reset() {
+	*x = MatchPlayer{}
+	mi := &file_warzone_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchPlayer) ProtoMessage() {}
+
+func (x *MatchPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchPlayer.ProtoReflect.Descriptor instead.
+func (*MatchPlayer) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MatchPlayer) GetUno() string {
+	if x != nil {
+		return x.Uno
+	}
+	return ""
+}
+
+func (x *MatchPlayer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *MatchPlayer) GetStats() *PlayerStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type Team struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Team       string                 `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	Placement  float64                `protobuf:"fixed64,2,opt,name=placement,proto3" json:"placement,omitempty"`
+	Kills      float64                `protobuf:"fixed64,3,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths     float64                `protobuf:"fixed64,4,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	DamageDone float64                `protobuf:"fixed64,5,opt,name=damage_done,json=damageDone,proto3" json:"damage_done,omitempty"`
+	// The time played in seconds of the member that stayed the longest.
+	SurvivalTime  float64        `protobuf:"fixed64,6,opt,name=survival_time,json=survivalTime,proto3" json:"survival_time,omitempty"`
+	Members       []*MatchPlayer `protobuf:"bytes,7,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_warzone_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Team) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *Team) GetPlacement() float64 {
+	if x != nil {
+		return x.Placement
+	}
+	return 0
+}
+
+func (x *Team) GetKills() float64 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *Team) GetDeaths() float64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *Team) GetDamageDone() float64 {
+	if x != nil {
+		return x.DamageDone
+	}
+	return 0
+}
+
+func (x *Team) GetSurvivalTime() float64 {
+	if x != nil {
+		return x.SurvivalTime
+	}
+	return 0
+}
+
+func (x *Team) GetMembers() []*MatchPlayer {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type GetMatchResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MatchId         string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Mode            string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Map             string                 `protobuf:"bytes,3,opt,name=map,proto3" json:"map,omitempty"`
+	MapName         string                 `protobuf:"bytes,4,opt,name=map_name,json=mapName,proto3" json:"map_name,omitempty"`
+	UtcStartSeconds int64                  `protobuf:"varint,5,opt,name=utc_start_seconds,json=utcStartSeconds,proto3" json:"utc_start_seconds,omitempty"`
+	// Sorted by placement, the first team is the winning team when winning_team is set.
+	Teams         []*Team `protobuf:"bytes,6,rep,name=teams,proto3" json:"teams,omitempty"`
+	WinningTeam   string  `protobuf:"bytes,7,opt,name=winning_team,json=winningTeam,proto3" json:"winning_team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchResponse) Reset() {
+	*x = GetMatchResponse{}
+	mi := &file_warzone_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchResponse) ProtoMessage() {}
+
+func (x *GetMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchResponse.ProtoReflect.Descriptor instead.
+func (*GetMatchResponse) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetMatchResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *GetMatchResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetMatchResponse) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+func (x *GetMatchResponse) GetMapName() string {
+	if x != nil {
+		return x.MapName
+	}
+	return ""
+}
+
+func (x *GetMatchResponse) GetUtcStartSeconds() int64 {
+	if x != nil {
+		return x.UtcStartSeconds
+	}
+	return 0
+}
+
+func (x *GetMatchResponse) GetTeams() []*Team {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *GetMatchResponse) GetWinningTeam() string {
+	if x != nil {
+		return x.WinningTeam
+	}
+	return ""
+}
+
+type GetSquadReportRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Players []*Player              `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	// 45 minutes when not set.
+	SessionGapSeconds int64 `protobuf:"varint,2,opt,name=session_gap_seconds,json=sessionGapSeconds,proto3" json:"session_gap_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetSquadReportRequest) Reset() {
+	*x = GetSquadReportRequest{}
+	mi := &file_warzone_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSquadReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSquadReportRequest) ProtoMessage() {}
+
+func (x *GetSquadReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSquadReportRequest.ProtoReflect.Descriptor instead.
+func (*GetSquadReportRequest) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetSquadReportRequest) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *GetSquadReportRequest) GetSessionGapSeconds() int64 {
+	if x != nil {
+		return x.SessionGapSeconds
+	}
+	return 0
+}
+
+type SessionPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Games         int32                  `protobuf:"varint,2,opt,name=games,proto3" json:"games,omitempty"`
+	Kills         float64                `protobuf:"fixed64,3,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths        float64                `protobuf:"fixed64,4,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	DamageDone    float64                `protobuf:"fixed64,5,opt,name=damage_done,json=damageDone,proto3" json:"damage_done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionPlayer) Reset() {
+	*x = SessionPlayer{}
+	mi := &file_warzone_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionPlayer) ProtoMessage() {}
+
+func (x *SessionPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionPlayer.ProtoReflect.Descriptor instead.
+func (*SessionPlayer) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionPlayer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SessionPlayer) GetGames() int32 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+func (x *SessionPlayer) GetKills() float64 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *SessionPlayer) GetDeaths() float64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *SessionPlayer) GetDamageDone() float64 {
+	if x != nil {
+		return x.DamageDone
+	}
+	return 0
+}
+
+type Session struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	StartSeconds     int64                  `protobuf:"varint,1,opt,name=start_seconds,json=startSeconds,proto3" json:"start_seconds,omitempty"`
+	EndSeconds       int64                  `protobuf:"varint,2,opt,name=end_seconds,json=endSeconds,proto3" json:"end_seconds,omitempty"`
+	TotalTimeSeconds int64                  `protobuf:"varint,3,opt,name=total_time_seconds,json=totalTimeSeconds,proto3" json:"total_time_seconds,omitempty"`
+	Games            int32                  `protobuf:"varint,4,opt,name=games,proto3" json:"games,omitempty"`
+	Wins             int32                  `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	Kills            float64                `protobuf:"fixed64,6,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths           float64                `protobuf:"fixed64,7,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	DamageDone       float64                `protobuf:"fixed64,8,opt,name=damage_done,json=damageDone,proto3" json:"damage_done,omitempty"`
+	AveragePlacement float64                `protobuf:"fixed64,9,opt,name=average_placement,json=averagePlacement,proto3" json:"average_placement,omitempty"`
+	BestMatchId      string                 `protobuf:"bytes,10,opt,name=best_match_id,json=bestMatchId,proto3" json:"best_match_id,omitempty"`
+	Players          []*SessionPlayer       `protobuf:"bytes,11,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_warzone_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Session) GetStartSeconds() int64 {
+	if x != nil {
+		return x.StartSeconds
+	}
+	return 0
+}
+
+func (x *Session) GetEndSeconds() int64 {
+	if x != nil {
+		return x.EndSeconds
+	}
+	return 0
+}
+
+func (x *Session) GetTotalTimeSeconds() int64 {
+	if x != nil {
+		return x.TotalTimeSeconds
+	}
+	return 0
+}
+
+func (x *Session) GetGames() int32 {
+	if x != nil {
+		return x.Games
+	}
+	return 0
+}
+
+func (x *Session) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *Session) GetKills() float64 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *Session) GetDeaths() float64 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *Session) GetDamageDone() float64 {
+	if x != nil {
+		return x.DamageDone
+	}
+	return 0
+}
+
+func (x *Session) GetAveragePlacement() float64 {
+	if x != nil {
+		return x.AveragePlacement
+	}
+	return 0
+}
+
+func (x *Session) GetBestMatchId() string {
+	if x != nil {
+		return x.BestMatchId
+	}
+	return ""
+}
+
+func (x *Session) GetPlayers() []*SessionPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type GetSquadReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSquadReportResponse) Reset() {
+	*x = GetSquadReportResponse{}
+	mi := &file_warzone_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSquadReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSquadReportResponse) ProtoMessage() {}
+
+func (x *GetSquadReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSquadReportResponse.ProtoReflect.Descriptor instead.
+func (*GetSquadReportResponse) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetSquadReportResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type WatchPlayerRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Player *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	// 5 minutes when not set, the minimum is 1 minute.
+	IntervalSeconds int64 `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchPlayerRequest) Reset() {
+	*x = WatchPlayerRequest{}
+	mi := &file_warzone_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPlayerRequest) ProtoMessage() {}
+
+func (x *WatchPlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPlayerRequest.ProtoReflect.Descriptor instead.
+func (*WatchPlayerRequest) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *WatchPlayerRequest) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *WatchPlayerRequest) GetIntervalSeconds() int64 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+type MatchEvent struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Player            *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Match             *Match                 `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
+	DetectedAtSeconds int64                  `protobuf:"varint,3,opt,name=detected_at_seconds,json=detectedAtSeconds,proto3" json:"detected_at_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MatchEvent) Reset() {
+	*x = MatchEvent{}
+	mi := &file_warzone_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchEvent) ProtoMessage() {}
+
+func (x *MatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_warzone_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchEvent.ProtoReflect.Descriptor instead.
+func (*MatchEvent) Descriptor() ([]byte, []int) {
+	return file_warzone_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MatchEvent) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *MatchEvent) GetMatch() *Match {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *MatchEvent) GetDetectedAtSeconds() int64 {
+	if x != nil {
+		return x.DetectedAtSeconds
+	}
+	return 0
+}
+
+var File_warzone_proto protoreflect.FileDescriptor
+
+const file_warzone_proto_rawDesc = "" +
+	"\n" +
+	"\rwarzone.proto\x12\n" +
+	"warzone.v1\"@\n" +
+	"\x06Player\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bplatform\x18\x02 \x01(\tR\bplatform\"\x8f\x03\n" +
+	"\vPlayerStats\x12\x14\n" +
+	"\x05kills\x18\x01 \x01(\x01R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\x02 \x01(\x01R\x06deaths\x12\x19\n" +
+	"\bkd_ratio\x18\x03 \x01(\x01R\akdRatio\x12\x18\n" +
+	"\aassists\x18\x04 \x01(\x01R\aassists\x12\x1c\n" +
+	"\theadshots\x18\x05 \x01(\x01R\theadshots\x12\x1f\n" +
+	"\vdamage_done\x18\x06 \x01(\x01R\n" +
+	"damageDone\x12!\n" +
+	"\fdamage_taken\x18\a \x01(\x01R\vdamageTaken\x12\x14\n" +
+	"\x05score\x18\b \x01(\x01R\x05score\x12\x1f\n" +
+	"\vgulag_kills\x18\t \x01(\x01R\n" +
+	"gulagKills\x12!\n" +
+	"\fgulag_deaths\x18\n" +
+	" \x01(\x01R\vgulagDeaths\x12%\n" +
+	"\x0eteam_placement\x18\v \x01(\x01R\rteamPlacement\x12\x1f\n" +
+	"\vtime_played\x18\f \x01(\x01R\n" +
+	"timePlayed\x12\x19\n" +
+	"\btotal_xp\x18\r \x01(\x01R\atotalXp\"\xfa\x01\n" +
+	"\x05Match\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x10\n" +
+	"\x03map\x18\x03 \x01(\tR\x03map\x12\x19\n" +
+	"\bmap_name\x18\x04 \x01(\tR\amapName\x12*\n" +
+	"\x11utc_start_seconds\x18\x05 \x01(\x03R\x0futcStartSeconds\x12&\n" +
+	"\x0futc_end_seconds\x18\x06 \x01(\x03R\rutcEndSeconds\x12\x12\n" +
+	"\x04team\x18\a \x01(\tR\x04team\x12-\n" +
+	"\x05stats\x18\b \x01(\v2\x17.warzone.v1.PlayerStatsR\x05stats\"[\n" +
+	"\x17GetRecentMatchesRequest\x12*\n" +
+	"\x06player\x18\x01 \x01(\v2\x12.warzone.v1.PlayerR\x06player\x12\x14\n" +
+	"\x05games\x18\x02 \x01(\x05R\x05games\"s\n" +
+	"\x18GetRecentMatchesResponse\x12*\n" +
+	"\x06player\x18\x01 \x01(\v2\x12.warzone.v1.PlayerR\x06player\x12+\n" +
+	"\amatches\x18\x02 \x03(\v2\x11.warzone.v1.MatchR\amatches\"\xe6\x02\n" +
+	"\rLifetimeStats\x12\x12\n" +
+	"\x04wins\x18\x01 \x01(\x01R\x04wins\x12\x14\n" +
+	"\x05kills\x18\x02 \x01(\x01R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\x03 \x01(\x01R\x06deaths\x12\x19\n" +
+	"\bkd_ratio\x18\x04 \x01(\x01R\akdRatio\x12\x14\n" +
+	"\x05downs\x18\x05 \x01(\x01R\x05downs\x12\x19\n" +
+	"\btop_five\x18\x06 \x01(\x01R\atopFive\x12\x17\n" +
+	"\atop_ten\x18\a \x01(\x01R\x06topTen\x12&\n" +
+	"\x0ftop_twenty_five\x18\b \x01(\x01R\rtopTwentyFive\x12\x18\n" +
+	"\arevives\x18\t \x01(\x01R\arevives\x12!\n" +
+	"\fgames_played\x18\n" +
+	" \x01(\x01R\vgamesPlayed\x12(\n" +
+	"\x10score_per_minute\x18\v \x01(\x01R\x0escorePerMinute\x12\x1f\n" +
+	"\vtime_played\x18\f \x01(\x01R\n" +
+	"timePlayed\"\xe0\x02\n" +
+	"\vWeeklyStats\x12\x14\n" +
+	"\x05kills\x18\x01 \x01(\x01R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\x02 \x01(\x01R\x06deaths\x12\x19\n" +
+	"\bkd_ratio\x18\x03 \x01(\x01R\akdRatio\x12%\n" +
+	"\x0ematches_played\x18\x04 \x01(\x01R\rmatchesPlayed\x12\x1f\n" +
+	"\vdamage_done\x18\x05 \x01(\x01R\n" +
+	"damageDone\x12!\n" +
+	"\fdamage_taken\x18\x06 \x01(\x01R\vdamageTaken\x12\x1f\n" +
+	"\vgulag_kills\x18\a \x01(\x01R\n" +
+	"gulagKills\x12!\n" +
+	"\fgulag_deaths\x18\b \x01(\x01R\vgulagDeaths\x12/\n" +
+	"\x13headshot_percentage\x18\t \x01(\x01R\x12headshotPercentage\x12(\n" +
+	"\x10score_per_minute\x18\n" +
+	" \x01(\x01R\x0escorePerMinute\"@\n" +
+	"\x12GetLifetimeRequest\x12*\n" +
+	"\x06player\x18\x01 \x01(\v2\x12.warzone.v1.PlayerR\x06player\"\xa4\x02\n" +
+	"\x13GetLifetimeResponse\x12*\n" +
+	"\x06player\x18\x01 \x01(\v2\x12.warzone.v1.PlayerR\x06player\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x01R\x05level\x12\x1a\n" +
+	"\bprestige\x18\x03 \x01(\x01R\bprestige\x12\x19\n" +
+	"\btotal_xp\x18\x04 \x01(\x01R\atotalXp\x12,\n" +
+	"\x12level_xp_remainder\x18\x05 \x01(\x01R\x10levelXpRemainder\x125\n" +
+	"\blifetime\x18\x06 \x01(\v2\x19.warzone.v1.LifetimeStatsR\blifetime\x12/\n" +
+	"\x06weekly\x18\a \x01(\v2\x17.warzone.v1.WeeklyStatsR\x06weekly\",\n" +
+	"\x0fGetMatchRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\"j\n" +
+	"\vMatchPlayer\x12\x10\n" +
+	"\x03uno\x18\x01 \x01(\tR\x03uno\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12-\n" +
+	"\x05stats\x18\x03 \x01(\v2\x17.warzone.v1.PlayerStatsR\x05stats\"\xdf\x01\n" +
+	"\x04Team\x12\x12\n" +
+	"\x04team\x18\x01 \x01(\tR\x04team\x12\x1c\n" +
+	"\tplacement\x18\x02 \x01(\x01R\tplacement\x12\x14\n" +
+	"\x05kills\x18\x03 \x01(\x01R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\x04 \x01(\x01R\x06deaths\x12\x1f\n" +
+	"\vdamage_done\x18\x05 \x01(\x01R\n" +
+	"damageDone\x12#\n" +
+	"\rsurvival_time\x18\x06 \x01(\x01R\fsurvivalTime\x121\n" +
+	"\amembers\x18\a \x03(\v2\x17.warzone.v1.MatchPlayerR\amembers\"\xe5\x01\n" +
+	"\x10GetMatchResponse\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x10\n" +
+	"\x03map\x18\x03 \x01(\tR\x03map\x12\x19\n" +
+	"\bmap_name\x18\x04 \x01(\tR\amapName\x12*\n" +
+	"\x11utc_start_seconds\x18\x05 \x01(\x03R\x0futcStartSeconds\x12&\n" +
+	"\x05teams\x18\x06 \x03(\v2\x10.warzone.v1.TeamR\x05teams\x12!\n" +
+	"\fwinning_team\x18\a \x01(\tR\vwinningTeam\"u\n" +
+	"\x15GetSquadReportRequest\x12,\n" +
+	"\aplayers\x18\x01 \x03(\v2\x12.warzone.v1.PlayerR\aplayers\x12.\n" +
+	"\x13session_gap_seconds\x18\x02 \x01(\x03R\x11sessionGapSeconds\"\x90\x01\n" +
+	"\rSessionPlayer\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05games\x18\x02 \x01(\x05R\x05games\x12\x14\n" +
+	"\x05kills\x18\x03 \x01(\x01R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\x04 \x01(\x01R\x06deaths\x12\x1f\n" +
+	"\vdamage_done\x18\x05 \x01(\x01R\n" +
+	"damageDone\"\xfc\x02\n" +
+	"\aSession\x12#\n" +
+	"\rstart_seconds\x18\x01 \x01(\x03R\fstartSeconds\x12\x1f\n" +
+	"\vend_seconds\x18\x02 \x01(\x03R\n" +
+	"endSeconds\x12,\n" +
+	"\x12total_time_seconds\x18\x03 \x01(\x03R\x10totalTimeSeconds\x12\x14\n" +
+	"\x05games\x18\x04 \x01(\x05R\x05games\x12\x12\n" +
+	"\x04wins\x18\x05 \x01(\x05R\x04wins\x12\x14\n" +
+	"\x05kills\x18\x06 \x01(\x01R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\a \x01(\x01R\x06deaths\x12\x1f\n" +
+	"\vdamage_done\x18\b \x01(\x01R\n" +
+	"damageDone\x12+\n" +
+	"\x11average_placement\x18\t \x01(\x01R\x10averagePlacement\x12\"\n" +
+	"\rbest_match_id\x18\n" +
+	" \x01(\tR\vbestMatchId\x123\n" +
+	"\aplayers\x18\v \x03(\v2\x19.warzone.v1.SessionPlayerR\aplayers\"I\n" +
+	"\x16GetSquadReportResponse\x12/\n" +
+	"\bsessions\x18\x01 \x03(\v2\x13.warzone.v1.SessionR\bsessions\"k\n" +
+	"\x12WatchPlayerRequest\x12*\n" +
+	"\x06player\x18\x01 \x01(\v2\x12.warzone.v1.PlayerR\x06player\x12)\n" +
+	"\x10interval_seconds\x18\x02 \x01(\x03R\x0fintervalSeconds\"\x91\x01\n" +
+	"\n" +
+	"MatchEvent\x12*\n" +
+	"\x06player\x18\x01 \x01(\v2\x12.warzone.v1.PlayerR\x06player\x12'\n" +
+	"\x05match\x18\x02 \x01(\v2\x11.warzone.v1.MatchR\x05match\x12.\n" +
+	"\x13detected_at_seconds\x18\x03 \x01(\x03R\x11detectedAtSeconds2\xa6\x03\n" +
+	"\fWarzoneSquad\x12]\n" +
+	"\x10GetRecentMatches\x12#.warzone.v1.GetRecentMatchesRequest\x1a$.warzone.v1.GetRecentMatchesResponse\x12N\n" +
+	"\vGetLifetime\x12\x1e.warzone.v1.GetLifetimeRequest\x1a\x1f.warzone.v1.GetLifetimeResponse\x12E\n" +
+	"\bGetMatch\x12\x1b.warzone.v1.GetMatchRequest\x1a\x1c.warzone.v1.GetMatchResponse\x12W\n" +
+	"\x0eGetSquadReport\x12!.warzone.v1.GetSquadReportRequest\x1a\".warzone.v1.GetSquadReportResponse\x12G\n" +
+	"\vWatchPlayer\x12\x1e.warzone.v1.WatchPlayerRequest\x1a\x16.warzone.v1.MatchEvent0\x01B3Z1github.com/NivNagli/WarzoneSquad_Go/api/warzonepbb\x06proto3"
+
+var (
+	file_warzone_proto_rawDescOnce sync.Once
+	file_warzone_proto_rawDescData []byte
+)
+
+func file_warzone_proto_rawDescGZIP() []byte {
+	file_warzone_proto_rawDescOnce.Do(func() {
+		file_warzone_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_warzone_proto_rawDesc), len(file_warzone_proto_rawDesc)))
+	})
+	return file_warzone_proto_rawDescData
+}
+
+var file_warzone_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_warzone_proto_goTypes = []any{
+	(*Player)(nil),                   // 0: warzone.v1.Player
+	(*PlayerStats)(nil),              // 1: warzone.v1.PlayerStats
+	(*Match)(nil),                    // 2: warzone.v1.Match
+	(*GetRecentMatchesRequest)(nil),  // 3: warzone.v1.GetRecentMatchesRequest
+	(*GetRecentMatchesResponse)(nil), // 4: warzone.v1.GetRecentMatchesResponse
+	(*LifetimeStats)(nil),            // 5: warzone.v1.LifetimeStats
+	(*WeeklyStats)(nil),              // 6: warzone.v1.WeeklyStats
+	(*GetLifetimeRequest)(nil),       // 7: warzone.v1.GetLifetimeRequest
+	(*GetLifetimeResponse)(nil),      // 8: warzone.v1.GetLifetimeResponse
+	(*GetMatchRequest)(nil),          // 9: warzone.v1.GetMatchRequest
+	(*MatchPlayer)(nil),              // 10: warzone.v1.MatchPlayer
+	(*Team)(nil),                     // 11: warzone.v1.Team
+	(*GetMatchResponse)(nil),         // 12: warzone.v1.GetMatchResponse
+	(*GetSquadReportRequest)(nil),    // 13: warzone.v1.GetSquadReportRequest
+	(*SessionPlayer)(nil),            // 14: warzone.v1.SessionPlayer
+	(*Session)(nil),                  // 15: warzone.v1.Session
+	(*GetSquadReportResponse)(nil),   // 16: warzone.v1.GetSquadReportResponse
+	(*WatchPlayerRequest)(nil),       // 17: warzone.v1.WatchPlayerRequest
+	(*MatchEvent)(nil),               // 18: warzone.v1.MatchEvent
+}
+var file_warzone_proto_depIdxs = []int32{
+	1,  // 0: warzone.v1.Match.stats:type_name -> warzone.v1.PlayerStats
+	0,  // 1: warzone.v1.GetRecentMatchesRequest.player:type_name -> warzone.v1.Player
+	0,  // 2: warzone.v1.GetRecentMatchesResponse.player:type_name -> warzone.v1.Player
+	2,  // 3: warzone.v1.GetRecentMatchesResponse.matches:type_name -> warzone.v1.Match
+	0,  // 4: warzone.v1.GetLifetimeRequest.player:type_name -> warzone.v1.Player
+	0,  // 5: warzone.v1.GetLifetimeResponse.player:type_name -> warzone.v1.Player
+	5,  // 6: warzone.v1.GetLifetimeResponse.lifetime:type_name -> warzone.v1.LifetimeStats
+	6,  // 7: warzone.v1.GetLifetimeResponse.weekly:type_name -> warzone.v1.WeeklyStats
+	1,  // 8: warzone.v1.MatchPlayer.stats:type_name -> warzone.v1.PlayerStats
+	10, // 9: warzone.v1.Team.members:type_name -> warzone.v1.MatchPlayer
+	11, // 10: warzone.v1.GetMatchResponse.teams:type_name -> warzone.v1.Team
+	0,  // 11: warzone.v1.GetSquadReportRequest.players:type_name -> warzone.v1.Player
+	14, // 12: warzone.v1.Session.players:type_name -> warzone.v1.SessionPlayer
+	15, // 13: warzone.v1.GetSquadReportResponse.sessions:type_name -> warzone.v1.Session
+	0,  // 14: warzone.v1.WatchPlayerRequest.player:type_name -> warzone.v1.Player
+	0,  // 15: warzone.v1.MatchEvent.player:type_name -> warzone.v1.Player
+	2,  // 16: warzone.v1.MatchEvent.match:type_name -> warzone.v1.Match
+	3,  // 17: warzone.v1.WarzoneSquad.GetRecentMatches:input_type -> warzone.v1.GetRecentMatchesRequest
+	7,  // 18: warzone.v1.WarzoneSquad.GetLifetime:input_type -> warzone.v1.GetLifetimeRequest
+	9,  // 19: warzone.v1.WarzoneSquad.GetMatch:input_type -> warzone.v1.GetMatchRequest
+	13, // 20: warzone.v1.WarzoneSquad.GetSquadReport:input_type -> warzone.v1.GetSquadReportRequest
+	17, // 21: warzone.v1.WarzoneSquad.WatchPlayer:input_type -> warzone.v1.WatchPlayerRequest
+	4,  // 22: warzone.v1.WarzoneSquad.GetRecentMatches:output_type -> warzone.v1.GetRecentMatchesResponse
+	8,  // 23: warzone.v1.WarzoneSquad.GetLifetime:output_type -> warzone.v1.GetLifetimeResponse
+	12, // 24: warzone.v1.WarzoneSquad.GetMatch:output_type -> warzone.v1.GetMatchResponse
+	16, // 25: warzone.v1.WarzoneSquad.GetSquadReport:output_type -> warzone.v1.GetSquadReportResponse
+	18, // 26: warzone.v1.WarzoneSquad.WatchPlayer:output_type -> warzone.v1.MatchEvent
+	22, // [22:27] is the sub-list for method output_type
+	17, // [17:22] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_warzone_proto_init() }
+func file_warzone_proto_init() {
+	if File_warzone_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_warzone_proto_rawDesc), len(file_warzone_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_warzone_proto_goTypes,
+		DependencyIndexes: file_warzone_proto_depIdxs,
+		MessageInfos:      file_warzone_proto_msgTypes,
+	}.Build()
+	File_warzone_proto = out.File
+	file_warzone_proto_goTypes = nil
+	file_warzone_proto_depIdxs = nil
+}
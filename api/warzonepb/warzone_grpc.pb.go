@@ -0,0 +1,290 @@
+// The gRPC API of WarzoneSquad, it expose the same data as the http server for services that work only with gRPC.
+// The Go code in this directory is generated from this file, see generate.go.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: warzone.proto
+
+package warzonepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WarzoneSquad_GetRecentMatches_FullMethodName = "/warzone.v1.WarzoneSquad/GetRecentMatches"
+	WarzoneSquad_GetLifetime_FullMethodName      = "/warzone.v1.WarzoneSquad/GetLifetime"
+	WarzoneSquad_GetMatch_FullMethodName         = "/warzone.v1.WarzoneSquad/GetMatch"
+	WarzoneSquad_GetSquadReport_FullMethodName   = "/warzone.v1.WarzoneSquad/GetSquadReport"
+	WarzoneSquad_WatchPlayer_FullMethodName      = "/warzone.v1.WarzoneSquad/WatchPlayer"
+)
+
+// WarzoneSquadClient is the client API for WarzoneSquad service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WarzoneSquadClient interface {
+	// GetRecentMatches return the recent matches of the player, more then 20 games are fetched in cycles.
+	GetRecentMatches(ctx context.Context, in *GetRecentMatchesRequest, opts ...grpc.CallOption) (*GetRecentMatchesResponse, error)
+	// GetLifetime return the profile, the lifetime and the weekly battle royale stats of the player.
+	GetLifetime(ctx context.Context, in *GetLifetimeRequest, opts ...grpc.CallOption) (*GetLifetimeResponse, error)
+	// GetMatch return all the teams of the match sorted by placement.
+	GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error)
+	// GetSquadReport return the sessions of the squad from the recent matches of all the players.
+	GetSquadReport(ctx context.Context, in *GetSquadReportRequest, opts ...grpc.CallOption) (*GetSquadReportResponse, error)
+	// WatchPlayer poll the player matches and stream every new match until the client cancel the call.
+	WatchPlayer(ctx context.Context, in *WatchPlayerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error)
+}
+
+type warzoneSquadClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWarzoneSquadClient(cc grpc.ClientConnInterface) WarzoneSquadClient {
+	return &warzoneSquadClient{cc}
+}
+
+func (c *warzoneSquadClient) GetRecentMatches(ctx context.Context, in *GetRecentMatchesRequest, opts ...grpc.CallOption) (*GetRecentMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRecentMatchesResponse)
+	err := c.cc.Invoke(ctx, WarzoneSquad_GetRecentMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warzoneSquadClient) GetLifetime(ctx context.Context, in *GetLifetimeRequest, opts ...grpc.CallOption) (*GetLifetimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLifetimeResponse)
+	err := c.cc.Invoke(ctx, WarzoneSquad_GetLifetime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warzoneSquadClient) GetMatch(ctx context.Context, in *GetMatchRequest, opts ...grpc.CallOption) (*GetMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMatchResponse)
+	err := c.cc.Invoke(ctx, WarzoneSquad_GetMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warzoneSquadClient) GetSquadReport(ctx context.Context, in *GetSquadReportRequest, opts ...grpc.CallOption) (*GetSquadReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSquadReportResponse)
+	err := c.cc.Invoke(ctx, WarzoneSquad_GetSquadReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warzoneSquadClient) WatchPlayer(ctx context.Context, in *WatchPlayerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WarzoneSquad_ServiceDesc.Streams[0], WarzoneSquad_WatchPlayer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPlayerRequest, MatchEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarzoneSquad_WatchPlayerClient = grpc.ServerStreamingClient[MatchEvent]
+
+// WarzoneSquadServer is the server API for WarzoneSquad service.
+// All implementations must embed UnimplementedWarzoneSquadServer
+// for forward compatibility.
+type WarzoneSquadServer interface {
+	// GetRecentMatches return the recent matches of the player, more then 20 games are fetched in cycles.
+	GetRecentMatches(context.Context, *GetRecentMatchesRequest) (*GetRecentMatchesResponse, error)
+	// GetLifetime return the profile, the lifetime and the weekly battle royale stats of the player.
+	GetLifetime(context.Context, *GetLifetimeRequest) (*GetLifetimeResponse, error)
+	// GetMatch return all the teams of the match sorted by placement.
+	GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error)
+	// GetSquadReport return the sessions of the squad from the recent matches of all the players.
+	GetSquadReport(context.Context, *GetSquadReportRequest) (*GetSquadReportResponse, error)
+	// WatchPlayer poll the player matches and stream every new match until the client cancel the call.
+	WatchPlayer(*WatchPlayerRequest, grpc.ServerStreamingServer[MatchEvent]) error
+	mustEmbedUnimplementedWarzoneSquadServer()
+}
+
+// UnimplementedWarzoneSquadServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWarzoneSquadServer struct{}
+
+func (UnimplementedWarzoneSquadServer) GetRecentMatches(context.Context, *GetRecentMatchesRequest) (*GetRecentMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentMatches not implemented")
+}
+func (UnimplementedWarzoneSquadServer) GetLifetime(context.Context, *GetLifetimeRequest) (*GetLifetimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLifetime not implemented")
+}
+func (UnimplementedWarzoneSquadServer) GetMatch(context.Context, *GetMatchRequest) (*GetMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatch not implemented")
+}
+func (UnimplementedWarzoneSquadServer) GetSquadReport(context.Context, *GetSquadReportRequest) (*GetSquadReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSquadReport not implemented")
+}
+func (UnimplementedWarzoneSquadServer) WatchPlayer(*WatchPlayerRequest, grpc.ServerStreamingServer[MatchEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPlayer not implemented")
+}
+func (UnimplementedWarzoneSquadServer) mustEmbedUnimplementedWarzoneSquadServer() {}
+func (UnimplementedWarzoneSquadServer) testEmbeddedByValue()                      {}
+
+// UnsafeWarzoneSquadServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WarzoneSquadServer will
+// result in compilation errors.
+type UnsafeWarzoneSquadServer interface {
+	mustEmbedUnimplementedWarzoneSquadServer()
+}
+
+func RegisterWarzoneSquadServer(s grpc.ServiceRegistrar, srv WarzoneSquadServer) {
+	// If the following call pancis, it indicates UnimplementedWarzoneSquadServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WarzoneSquad_ServiceDesc, srv)
+}
+
+func _WarzoneSquad_GetRecentMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarzoneSquadServer).GetRecentMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarzoneSquad_GetRecentMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarzoneSquadServer).GetRecentMatches(ctx, req.(*GetRecentMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarzoneSquad_GetLifetime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLifetimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarzoneSquadServer).GetLifetime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarzoneSquad_GetLifetime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarzoneSquadServer).GetLifetime(ctx, req.(*GetLifetimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarzoneSquad_GetMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarzoneSquadServer).GetMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarzoneSquad_GetMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarzoneSquadServer).GetMatch(ctx, req.(*GetMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarzoneSquad_GetSquadReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSquadReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarzoneSquadServer).GetSquadReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarzoneSquad_GetSquadReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarzoneSquadServer).GetSquadReport(ctx, req.(*GetSquadReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarzoneSquad_WatchPlayer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPlayerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WarzoneSquadServer).WatchPlayer(m, &grpc.GenericServerStream[WatchPlayerRequest, MatchEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WarzoneSquad_WatchPlayerServer = grpc.ServerStreamingServer[MatchEvent]
+
+// WarzoneSquad_ServiceDesc is the grpc.ServiceDesc for WarzoneSquad service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WarzoneSquad_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "warzone.v1.WarzoneSquad",
+	HandlerType: (*WarzoneSquadServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRecentMatches",
+			Handler:    _WarzoneSquad_GetRecentMatches_Handler,
+		},
+		{
+			MethodName: "GetLifetime",
+			Handler:    _WarzoneSquad_GetLifetime_Handler,
+		},
+		{
+			MethodName: "GetMatch",
+			Handler:    _WarzoneSquad_GetMatch_Handler,
+		},
+		{
+			MethodName: "GetSquadReport",
+			Handler:    _WarzoneSquad_GetSquadReport_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPlayer",
+			Handler:       _WarzoneSquad_WatchPlayer_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "warzone.proto",
+}
@@ -0,0 +1,18 @@
+// Package app is responsible for starting the http server of the application,
+// the routes of the server are defined in the url_mappings.go file.
+
+package app
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// StartApp register all the routes and start listening on the given address, the function return only when the server stopped.
+// The caller should register the activision metrics with metrics.RegisterActivisionMetrics before, the /metrics route expose them.
+func StartApp(addr string) error {
+	mux := http.NewServeMux()
+	mapUrls(mux)
+	slog.Info("starting the http server", slog.String("addr", addr))
+	return http.ListenAndServe(addr, mux)
+}
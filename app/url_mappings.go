@@ -0,0 +1,15 @@
+package app
+
+import (
+	"net/http"
+
+	"github.com/NivNagli/WarzoneSquad_Go/controllers"
+	"github.com/NivNagli/WarzoneSquad_Go/metrics"
+)
+
+func mapUrls(mux *http.ServeMux) {
+	mux.HandleFunc("/compare", controllers.ComparePlayers)
+	mux.HandleFunc("/graphql", controllers.GraphQL)
+	mux.HandleFunc("/leaderboard", controllers.GetLeaderboard)
+	mux.Handle("/metrics", metrics.Handler())
+}
@@ -9,11 +9,18 @@ package restclient
 
 import (
 	"bytes"
-
+	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 )
 
+// DefaultTimeout is the time limit of a request, including reading the response body.
+const DefaultTimeout = 30 * time.Second
+
+// client is shared by all the requests so the connections are reused.
+var client = &http.Client{Timeout: DefaultTimeout}
+
 func Get(url string, body interface{}, headers http.Header) (*http.Response, error) {
 	jsonBytes, err := json.Marshal(body)
 	if err != nil {
@@ -26,6 +33,21 @@ func Get(url string, body interface{}, headers http.Header) (*http.Response, err
 	}
 	request.Header = headers
 
-	client := http.Client{}
+	return client.Do(request)
+}
+
+// Post send the body as json, the request is canceled when the context is canceled.
+func Post(ctx context.Context, url string, body interface{}, headers http.Header) (*http.Response, error) {
+	jsonBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBytes))
+	if err != nil {
+		return nil, err
+	}
+	request.Header = headers
+
 	return client.Do(request)
 }
@@ -0,0 +1,523 @@
+// This file contains the implementation of the CLI sub commands.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/app"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/grpc_server"
+	"github.com/NivNagli/WarzoneSquad_Go/livefeed"
+	"github.com/NivNagli/WarzoneSquad_Go/metrics"
+	"github.com/NivNagli/WarzoneSquad_Go/notifier"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+	"github.com/NivNagli/WarzoneSquad_Go/reports"
+	"github.com/NivNagli/WarzoneSquad_Go/services"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+)
+
+// playersFlag is a flag that can be repeated, every value is a platform:username player.
+type playersFlag []string
+
+func (p *playersFlag) String() string { return strings.Join(*p, ",") }
+
+func (p *playersFlag) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// loggingFlags add the logging flags to the command flags, the returned function must be called after the flags are parsed.
+func loggingFlags(fs *flag.FlagSet) func() error {
+	level := fs.String("log-level", "info", "the minimum log level: debug, info, warn or error")
+	asJson := fs.Bool("log-json", false, "write the logs as json")
+	redact := fs.Bool("redact-usernames", false, "redact the usernames in the logs")
+	return func() error {
+		var logLevel slog.Level
+		if err := logLevel.UnmarshalText([]byte(*level)); err != nil {
+			return fmt.Errorf("invalid log level '%s'\n", *level)
+		}
+		options := &slog.HandlerOptions{Level: logLevel}
+		var handler slog.Handler = slog.NewTextHandler(os.Stderr, options)
+		if *asJson {
+			handler = slog.NewJSONHandler(os.Stderr, options)
+		}
+		logger := slog.New(handler)
+		slog.SetDefault(logger)
+		activision_providers.SetLogger(logger)
+		activision_providers.SetRedactUsernames(*redact)
+		return nil
+	}
+}
+
+// parsePlayers convert the "platform:username" arguments into requests.
+func parsePlayers(args []string) ([]activision.LastGamesRequest, error) {
+	var players []activision.LastGamesRequest
+	for _, p := range args {
+		player, err := services.ParsePlayer(p)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, player)
+	}
+	return players, nil
+}
+
+// useStore open the matches store directory and set it as the store of the services.
+func useStore(dir string) error {
+	matchesStore, err := store.NewFileStore(dir)
+	if err != nil {
+		return err
+	}
+	services.SetStore(matchesStore)
+	return nil
+}
+
+// DefaultCacheTTL is the default time that the servers keep the activision responses, it is shorter than the minimum
+// interval of the grpc WatchPlayer stream so the polls always get fresh games.
+const DefaultCacheTTL = 30 * time.Second
+
+// useCache add the cache middleware to the providers pipeline, the cache is disabled when ttl <= 0.
+// It must be called after the metrics middleware was registered so the cache hits are counted.
+func useCache(ttl time.Duration) {
+	if ttl > 0 {
+		activision_providers.Use(activision_providers.CacheMiddleware(ttl, activision_providers.DefaultCacheEntries))
+	}
+}
+
+func runMatch(args []string) error {
+	fs := flag.NewFlagSet("match", flag.ContinueOnError)
+	asJson := fs.Bool("json", false, "print the full match as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: match [-json] <gameID>\n")
+	}
+	result, err := activision_providers.GetGameStatsByID(activision.SpecificGameStatsRequest{GameID: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderMatch(os.Stdout, fs.Arg(0), *result)
+}
+
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	games := fs.Int("games", 20, "number of recent games to compare")
+	asJson := fs.Bool("json", false, "print the comparison as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	var players []activision.ActivisionRequest
+	for _, p := range fs.Args() {
+		player, err := services.ParsePlayer(p)
+		if err != nil {
+			return err
+		}
+		players = append(players, player)
+	}
+	result, err := services.ComparePlayers(players, *games)
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderComparison(os.Stdout, *result)
+}
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "the address for the http server")
+	storeDir := fs.String("store", "matches", "directory of the matches that the watch mode saved")
+	cacheTTL := fs.Duration("cache-ttl", DefaultCacheTTL, "time to keep the activision responses in memory, 0 to disable the cache")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if err := useStore(*storeDir); err != nil {
+		return err
+	}
+	// The activision requests metrics are exposed by the /metrics route of the server.
+	metrics.RegisterActivisionMetrics()
+	useCache(*cacheTTL)
+	return app.StartApp(*addr)
+}
+
+func runGrpc(args []string) error {
+	fs := flag.NewFlagSet("grpc", flag.ContinueOnError)
+	addr := fs.String("addr", ":9090", "the address for the grpc server")
+	cacheTTL := fs.Duration("cache-ttl", DefaultCacheTTL, "time to keep the activision responses in memory, 0 to disable the cache")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	useCache(*cacheTTL)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	slog.Info("grpc server started", slog.String("addr", *addr))
+	return grpc_server.Start(ctx, *addr)
+}
+
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	configPath := fs.String("config", "", "json file with the squads to track, see watcher/config.go")
+	var players playersFlag
+	fs.Var(&players, "player", "platform:username of a player to track alone, can be repeated")
+	interval := fs.Duration("interval", 0, "time between the polls, overrides the config file (default 5m)")
+	jitter := fs.Duration("jitter", 0, "maximum random time that is added to every interval, overrides the config file")
+	storeDir := fs.String("store", "matches", "directory for the saved matches")
+	rate := fs.Duration("rate", 2*time.Second, "minimum time between two requests to the activision API")
+	webhooksPath := fs.String("webhooks", "", "json file with discord or slack webhooks that receive the new matches, see notifier/webhook.go")
+	notifyExisting := fs.Bool("notify-existing", false, "report the last games of a new tracked player instead of only saving them")
+	feedAddr := fs.String("feed-addr", "", "address for the websocket live feed of the new matches on /live, disabled when empty")
+	feedOrigins := fs.String("feed-origins", "", "comma separated origins that can connect to the live feed, same origin only when empty")
+	metricsAddr := fs.String("metrics-addr", "", "address for the prometheus metrics of the activision requests on /metrics, disabled when empty")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	var config watcher.Config
+	if *configPath != "" {
+		var err error
+		if config, err = watcher.LoadConfig(*configPath); err != nil {
+			return err
+		}
+	}
+	for _, p := range players {
+		player, err := services.ParsePlayer(p)
+		if err != nil {
+			return err
+		}
+		config.Squads = append(config.Squads, watcher.Squad{Name: p, Players: []activision.LastGamesRequest{player}})
+	}
+	if len(config.Squads) == 0 {
+		return fmt.Errorf("usage: watch -config squads.json or watch -player platform:username\n")
+	}
+	if *interval > 0 {
+		config.Interval = *interval
+	}
+	if *jitter > 0 {
+		config.Jitter = *jitter
+	}
+	config.NotifyExisting = config.NotifyExisting || *notifyExisting
+	matchesStore, err := store.NewFileStore(*storeDir)
+	if err != nil {
+		return err
+	}
+	config.Store = matchesStore
+	config.Sinks = []watcher.Sink{watcher.LogSink}
+	if *webhooksPath != "" {
+		webhooks, err := notifier.LoadWebhooks(*webhooksPath)
+		if err != nil {
+			return err
+		}
+		webhooksNotifier, err := notifier.New(webhooks)
+		if err != nil {
+			return err
+		}
+		config.Sinks = append(config.Sinks, webhooksNotifier)
+	}
+	if *metricsAddr != "" {
+		// The metrics middleware must be registered before the retry middleware so it will see the number of attempts.
+		metrics.RegisterActivisionMetrics()
+	}
+	activision_providers.Use(activision_providers.RetryMiddleware(3, time.Second), activision_providers.RateLimitMiddleware(*rate))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// The live feed and the metrics can share the same address.
+	servers := make(map[string]*http.ServeMux)
+	serverMux := func(addr string) *http.ServeMux {
+		if _, ok := servers[addr]; !ok {
+			servers[addr] = http.NewServeMux()
+		}
+		return servers[addr]
+	}
+	if *feedAddr != "" {
+		var origins []string
+		if *feedOrigins != "" {
+			origins = strings.Split(*feedOrigins, ",")
+		}
+		hub := livefeed.NewHub(livefeed.Options{AllowedOrigins: origins})
+		defer hub.Close()
+		config.Sinks = append(config.Sinks, hub)
+		serverMux(*feedAddr).Handle("/live", hub)
+		slog.Info("starting the live feed", slog.String("addr", *feedAddr))
+	}
+	if *metricsAddr != "" {
+		serverMux(*metricsAddr).Handle("/metrics", metrics.Handler())
+		slog.Info("starting the metrics server", slog.String("addr", *metricsAddr))
+	}
+	for addr, mux := range servers {
+		server := &http.Server{Addr: addr, Handler: mux}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				slog.Error("http server stopped", slog.String("addr", server.Addr), slog.String("error", err.Error()))
+			}
+		}()
+		defer server.Close()
+	}
+	slog.Info("watching squads", slog.Int("squads", len(config.Squads)), slog.Duration("interval", config.Interval))
+	return watcher.New(config).Run(ctx)
+}
+
+func runLeaderboard(args []string) error {
+	fs := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	window := fs.String("window", analytics.WindowWeek, "the games window: "+strings.Join(analytics.Windows, ", "))
+	metric := fs.String("metric", analytics.MetricKd, "the ranking metric: "+strings.Join(analytics.Metrics, ", "))
+	storeDir := fs.String("store", "matches", "directory of the matches that the watch mode saved")
+	asJson := fs.Bool("json", false, "print the leaderboard as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if err := useStore(*storeDir); err != nil {
+		return err
+	}
+	result, err := services.GetLeaderboard(*window, *metric)
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderLeaderboard(os.Stdout, *result)
+}
+
+func runGulag(args []string) error {
+	fs := flag.NewFlagSet("gulag", flag.ContinueOnError)
+	storeDir := fs.String("store", "matches", "directory of the matches that the watch mode saved")
+	asJson := fs.Bool("json", false, "print the report as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	players, err := parsePlayers(fs.Args())
+	if err != nil {
+		return err
+	}
+	if err := useStore(*storeDir); err != nil {
+		return err
+	}
+	result, err := services.GetSquadGulag(players)
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderSquadGulag(os.Stdout, *result)
+}
+
+func runObjectives(args []string) error {
+	fs := flag.NewFlagSet("objectives", flag.ContinueOnError)
+	games := fs.Int("games", 20, "number of recent games")
+	asJson := fs.Bool("json", false, "print the report as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: objectives [-games N] platform:username\n")
+	}
+	player, err := services.ParsePlayer(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	result, err := services.GetSquadObjectives(player, *games)
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderObjectives(os.Stdout, *result)
+}
+
+func runProgress(args []string) error {
+	fs := flag.NewFlagSet("progress", flag.ContinueOnError)
+	games := fs.Int("games", 20, "number of recent games for the xp rate")
+	asJson := fs.Bool("json", false, "print the progression as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: progress [-games N] platform:username\n")
+	}
+	player, err := services.ParsePlayer(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	result, err := services.GetProgression(player, *games)
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderProgression(os.Stdout, *result)
+}
+
+func runMaps(args []string) error {
+	fs := flag.NewFlagSet("maps", flag.ContinueOnError)
+	storeDir := fs.String("store", "matches", "directory of the matches that the watch mode saved")
+	asJson := fs.Bool("json", false, "print the report as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	players, err := parsePlayers(fs.Args())
+	if err != nil {
+		return err
+	}
+	if err := useStore(*storeDir); err != nil {
+		return err
+	}
+	result, err := services.GetSquadMaps(players)
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderMaps(os.Stdout, result)
+}
+
+func runAwards(args []string) error {
+	fs := flag.NewFlagSet("awards", flag.ContinueOnError)
+	games := fs.Int("games", 20, "number of recent games of every player")
+	storeDir := fs.String("store", "", "read the matches that the watch mode saved in this directory instead of the recent games")
+	asJson := fs.Bool("json", false, "print the awards as json")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	players, err := parsePlayers(fs.Args())
+	if err != nil {
+		return err
+	}
+	var result []analytics.PlayerAwards
+	if *storeDir != "" {
+		if err := useStore(*storeDir); err != nil {
+			return err
+		}
+		result, err = services.GetSavedAwards(players)
+	} else {
+		if len(players) == 0 {
+			return fmt.Errorf("usage: awards [-games N] [-store dir] [-json] platform:username...\n")
+		}
+		result, err = services.GetRecentAwards(players, *games)
+	}
+	if err != nil {
+		return err
+	}
+	if *asJson {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+	return reports.RenderPlayersAwards(os.Stdout, result)
+}
+
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	games := fs.Int("games", 20, "number of recent games of every player")
+	storeDir := fs.String("store", "", "read the matches that the watch mode saved in this directory instead of the recent games")
+	out := fs.String("out", "report.html", "the html file to write")
+	title := fs.String("title", "", "the title of the report (default the player names)")
+	window := fs.Int("window", analytics.DefaultTrendWindow, "number of games of the rolling averages in the trends")
+	top := fs.Int("top", analytics.DefaultTopGames, "number of top games")
+	setupLogging := loggingFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	players, err := parsePlayers(fs.Args())
+	if err != nil {
+		return err
+	}
+	options := analytics.ReportOptions{TrendWindow: *window, TopGames: *top}
+	var result *analytics.MatchesReport
+	if *storeDir != "" {
+		if err := useStore(*storeDir); err != nil {
+			return err
+		}
+		result, err = services.GetSavedMatchesReport(players, options)
+	} else {
+		if len(players) == 0 {
+			return fmt.Errorf("usage: report [-games N] [-store dir] [-out report.html] platform:username...\n")
+		}
+		result, err = services.GetRecentMatchesReport(players, *games, options)
+	}
+	if err != nil {
+		return err
+	}
+	if *title == "" {
+		*title = "Warzone report: " + strings.Join(result.Players, ", ")
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := reports.RenderHTML(file, *title, *result, time.Now()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("report written to %s\n", *out)
+	return nil
+}
@@ -0,0 +1,56 @@
+// Package controllers contains the http handlers of the application, the handlers only read the request arguments,
+// call the services package and write the result back as json (or as text report when format=text).
+
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/reports"
+	"github.com/NivNagli/WarzoneSquad_Go/services"
+)
+
+// ComparePlayers handle GET /compare?player=uno:name%231234&player=psn:name&games=20
+func ComparePlayers(w http.ResponseWriter, r *http.Request) {
+	var players []activision.ActivisionRequest
+	for _, p := range r.URL.Query()["player"] {
+		player, err := services.ParsePlayer(p)
+		if err != nil {
+			respondError(w, err)
+			return
+		}
+		players = append(players, player)
+	}
+	games, err := intQueryParam(r, "games", 20)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+
+	result, err := services.ComparePlayers(players, games)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		reports.RenderComparison(w, *result)
+		return
+	}
+	respondJson(w, http.StatusOK, result)
+}
+
+// intQueryParam read integer query parameter and return the default value when the parameter is missing.
+func intQueryParam(r *http.Request, name string, defaultValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &activision.ActivisionErrorResponse{Message: "Error: invalid '" + name + "' query parameter\n", StatusCode: http.StatusBadRequest}
+	}
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/graph"
+)
+
+// GraphQL handle POST /graphql with json body {"query": "...", "operationName": "...", "variables": {...}}
+// and GET /graphql?query=...&variables={...}, the query errors are returned inside the response with status 200.
+func GraphQL(w http.ResponseWriter, r *http.Request) {
+	var request graph.Request
+	switch r.Method {
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			respondError(w, activision.NewValidationError("body", "", "the body must be json with query, operationName and variables"))
+			return
+		}
+	case http.MethodGet:
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				respondError(w, activision.NewValidationError("variables", variables, "variables must be json object"))
+				return
+			}
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		respondJson(w, http.StatusMethodNotAllowed, activision.ActivisionErrorResponse{Message: "Error: only GET and POST are allowed\n", StatusCode: http.StatusMethodNotAllowed})
+		return
+	}
+	if request.Query == "" {
+		respondError(w, activision.NewValidationError("query", "", "query is required"))
+		return
+	}
+	respondJson(w, http.StatusOK, graph.Execute(r.Context(), request))
+}
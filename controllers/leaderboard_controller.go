@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/reports"
+	"github.com/NivNagli/WarzoneSquad_Go/services"
+)
+
+// GetLeaderboard handle GET /leaderboard?window=week&metric=kd
+func GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	window := stringQueryParam(r, "window", analytics.WindowWeek)
+	metric := stringQueryParam(r, "metric", analytics.MetricKd)
+	result, err := services.GetLeaderboard(window, metric)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		reports.RenderLeaderboard(w, *result)
+		return
+	}
+	respondJson(w, http.StatusOK, result)
+}
+
+// stringQueryParam read query parameter and return the default value when the parameter is missing.
+func stringQueryParam(r *http.Request, name string, defaultValue string) string {
+	if value := r.URL.Query().Get(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,42 @@
+// This file contains the shared functions for writing the http responses.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+func respondJson(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// respondError write the error as json, our providers return ActivisionErrorResponse, ValidationError or SchemaDriftError with the status code that we set
+// so we use it as the http status, any other error is returned as internal server error.
+func respondError(w http.ResponseWriter, err error) {
+	var validationErr *activision.ValidationError
+	if errors.As(err, &validationErr) {
+		respondJson(w, validationErr.StatusCode, validationErr)
+		return
+	}
+	var driftErr *activision.SchemaDriftError
+	if errors.As(err, &driftErr) {
+		respondJson(w, driftErr.StatusCode, driftErr)
+		return
+	}
+	var apiErr *activision.ActivisionErrorResponse
+	if errors.As(err, &apiErr) {
+		status := apiErr.StatusCode
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		respondJson(w, status, apiErr)
+		return
+	}
+	respondJson(w, http.StatusInternalServerError, activision.ActivisionErrorResponse{Message: err.Error(), StatusCode: http.StatusInternalServerError})
+}
@@ -19,3 +19,21 @@ type ActivisionErrorResponseData struct {
 func (e ActivisionErrorResponse) Error() string {
 	return e.Message
 }
+
+// ValidationError is returned when one of the request arguments that we received is invalid,
+// Field is the name of the argument (username, platform, gameID...) so the caller will know what to fix.
+type ValidationError struct {
+	StatusCode int    `json:"status_code"`
+	Field      string `json:"field"`
+	Value      string `json:"value"`
+	Message    string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	return "Error: invalid " + e.Field + " '" + e.Value + "': " + e.Message + "\n"
+}
+
+// NewValidationError create ValidationError with the bad request status code.
+func NewValidationError(field string, value string, message string) *ValidationError {
+	return &ValidationError{StatusCode: 400, Field: field, Value: value, Message: message}
+}
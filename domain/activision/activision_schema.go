@@ -0,0 +1,295 @@
+// This file is responsible for finding changes in the structure of the official API responses (schema drift),
+// because our domain objects ignore the fields that they don't know, a change in the response will silently produce zero values.
+// So we compare the live payload with the responses that i saved in the OfficialResponsesFromActiApi folder and we check that the
+// fields that we can't work without are not empty.
+
+package activision
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed OfficialResponsesFromActiApi/*.json
+var fixtures embed.FS
+
+// The names of the saved responses that the live payloads are compared with.
+const (
+	FixtureLastGames         = "lastGamesResponse.json"
+	FixtureLifetimeAndWeekly = "lifetimeAndweeklyResponse.json"
+	FixtureGameByID          = "successResponseFromMatchID.json"
+)
+
+// fixtureRoots holds the fixtures that was saved with an extra wrapper object (postman saved them that way),
+// the value is the key that we need to enter in order to get the real response.
+var fixtureRoots = map[string]string{
+	FixtureGameByID: "data",
+}
+
+// dynamicPaths are parts of the responses that their keys changes from response to response (awards, modes, objectives counters...),
+// the matching key is replaced with the pattern segment so they will not be reported as new or removed fields.
+// '*' match any key and 'prefix*' match any key that starts with the prefix.
+var dynamicPaths = []string{
+	"data.summary.*",
+	"data.matches[].player.awards.*",
+	"data.allPlayers[].player.awards.*",
+	"data.matches[].player.brMissionStats.missionStatsByType.*",
+	"data.allPlayers[].player.brMissionStats.missionStatsByType.*",
+	"data.matches[].playerStats.objective*",
+	"data.allPlayers[].playerStats.objective*",
+	"data.lifetime.mode.*",
+	"data.lifetime.map.*",
+	"data.lifetime.itemData.*",
+	"data.lifetime.scorestreakData.*",
+	"data.lifetime.accoladeData.*",
+	"data.weekly.mode.*",
+	"data.weekly.map.*",
+}
+
+// requiredPaths are the fields of every fixture that our domain objects can't work without, only those fields are reported
+// as removed because a single saved response does not tell which of its fields are optional (the official API does not
+// return stats that the player does not have). The paths are in the format of the compared paths, '[]' for array elements.
+var requiredPaths = map[string][]string{
+	FixtureLastGames: {
+		"status",
+		"data.matches[].matchID",
+		"data.matches[].utcStartSeconds",
+		"data.matches[].utcEndSeconds",
+		"data.matches[].mode",
+		"data.matches[].map",
+		"data.matches[].player.uno",
+		"data.matches[].player.username",
+		"data.matches[].player.team",
+		"data.matches[].playerStats.kills",
+		"data.matches[].playerStats.deaths",
+		"data.matches[].playerStats.damageDone",
+		"data.matches[].playerStats.teamPlacement",
+		"data.matches[].playerStats.timePlayed",
+	},
+	FixtureLifetimeAndWeekly: {
+		"status",
+		"data.username",
+		"data.platform",
+		"data.level",
+		"data.prestige",
+	},
+	FixtureGameByID: {
+		"status",
+		"data.allPlayers[].matchID",
+		"data.allPlayers[].utcStartSeconds",
+		"data.allPlayers[].utcEndSeconds",
+		"data.allPlayers[].mode",
+		"data.allPlayers[].map",
+		"data.allPlayers[].player.uno",
+		"data.allPlayers[].player.username",
+		"data.allPlayers[].player.team",
+		"data.allPlayers[].playerStats.kills",
+		"data.allPlayers[].playerStats.deaths",
+		"data.allPlayers[].playerStats.damageDone",
+		"data.allPlayers[].playerStats.teamPlacement",
+		"data.allPlayers[].playerStats.timePlayed",
+	},
+}
+
+// SchemaDrift describe the differences that we found between the live response and the saved response of the same endpoint.
+// UnmodeledField is filled only in strict decoding and it is the first field of the response that our domain objects does not have,
+// it is not counted as drift because most of the response fields are not modeled on purpose.
+type SchemaDrift struct {
+	Endpoint        string   `json:"endpoint"`
+	MissingRequired []string `json:"missingRequired,omitempty"`
+	NewFields       []string `json:"newFields,omitempty"`
+	RemovedFields   []string `json:"removedFields,omitempty"`
+	UnmodeledField  string   `json:"unmodeledField,omitempty"`
+}
+
+// HasDrift return true when the live response is different from what we expect.
+func (d SchemaDrift) HasDrift() bool {
+	return len(d.MissingRequired) > 0 || len(d.NewFields) > 0 || len(d.RemovedFields) > 0
+}
+
+func (d SchemaDrift) Error() string {
+	return fmt.Sprintf("Error: schema drift detected in %s response: missing required %v, new fields %v, removed fields %v\n",
+		d.Endpoint, d.MissingRequired, d.NewFields, d.RemovedFields)
+}
+
+// SchemaDriftError is returned when we run in strict mode and the response structure was changed.
+type SchemaDriftError struct {
+	StatusCode int         `json:"status_code"`
+	Drift      SchemaDrift `json:"drift"`
+}
+
+func (e SchemaDriftError) Error() string {
+	return e.Drift.Error()
+}
+
+// RequiredFieldsValidator is implemented by the response domain objects, it return the paths of the required fields that are empty.
+type RequiredFieldsValidator interface {
+	MissingRequiredFields() []string
+}
+
+/***************************************** Functions for comparing with the fixtures *****************************************/
+
+var (
+	fixturePathsMutex sync.Mutex
+	fixturePaths      = make(map[string]map[string]bool)
+)
+
+// CompareWithFixture compare the fields of the payload with the fields of the saved fixture, it return the fields that exist only in the payload
+// and the required fields (see requiredPaths) that are missing from the payload, both sorted.
+// A required field inside an array that is empty in the payload (the last page of the games for example) is not reported,
+// an empty array is no evidence that the field was removed.
+func CompareWithFixture(fixture string, payload []byte) (newFields []string, removedFields []string, err error) {
+	expected, err := loadFixturePaths(fixture)
+	if err != nil {
+		return nil, nil, err
+	}
+	live, err := payloadPaths(payload, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	for p := range live {
+		if !expected[p] {
+			newFields = append(newFields, p)
+		}
+	}
+	for _, p := range requiredPaths[fixture] {
+		if expected[p] && !live[p] && !inEmptyArray(p, live) {
+			removedFields = append(removedFields, p)
+		}
+	}
+	sort.Strings(newFields)
+	sort.Strings(removedFields)
+	return newFields, removedFields, nil
+}
+
+// inEmptyArray return true when one of the arrays of the path is empty or null in the payload,
+// the paths of an empty array (and of a null value) end at the array itself.
+func inEmptyArray(path string, live map[string]bool) bool {
+	for i := strings.Index(path, "[]"); i >= 0; {
+		if live[path[:i]] {
+			return true
+		}
+		next := strings.Index(path[i+2:], "[]")
+		if next < 0 {
+			break
+		}
+		i += 2 + next
+	}
+	return false
+}
+
+// DecodeStrict decode the payload into v and fail on the first field that v does not have,
+// it is used to find which fields of the response are not modeled by our domain objects.
+func DecodeStrict(payload []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// UnknownFieldName extract the field name from the error that the json decoder return for unknown field, in case the error
+// is not unknown field error we return empty string.
+func UnknownFieldName(err error) string {
+	const prefix = "json: unknown field "
+	if err == nil || !strings.HasPrefix(err.Error(), prefix) {
+		return ""
+	}
+	return strings.Trim(strings.TrimPrefix(err.Error(), prefix), `"`)
+}
+
+// loadFixturePaths read the fixture only once and keep its fields paths in memory because the match fixture is very large.
+func loadFixturePaths(fixture string) (map[string]bool, error) {
+	fixturePathsMutex.Lock()
+	defer fixturePathsMutex.Unlock()
+	if paths, ok := fixturePaths[fixture]; ok {
+		return paths, nil
+	}
+	payload, err := fixtures.ReadFile("OfficialResponsesFromActiApi/" + fixture)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := payloadPaths(payload, fixtureRoots[fixture])
+	if err != nil {
+		return nil, err
+	}
+	fixturePaths[fixture] = paths
+	return paths, nil
+}
+
+// payloadPaths return the paths of all the leaf fields of the json payload, array elements are marked with '[]' so all the
+// elements of the same array share the same paths.
+func payloadPaths(payload []byte, root string) (map[string]bool, error) {
+	var value interface{}
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return nil, err
+	}
+	if root != "" {
+		if object, ok := value.(map[string]interface{}); ok {
+			value = object[root]
+		}
+	}
+	paths := make(map[string]bool)
+	collectPaths(value, nil, paths)
+	return paths, nil
+}
+
+func collectPaths(value interface{}, path []string, paths map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			paths[normalizePath(path)] = true
+		}
+		for key, child := range v {
+			collectPaths(child, append(path, key), paths)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			paths[normalizePath(path)] = true
+		}
+		for _, child := range v {
+			if len(path) == 0 {
+				collectPaths(child, []string{"[]"}, paths)
+				continue
+			}
+			arrayPath := append([]string(nil), path...)
+			arrayPath[len(arrayPath)-1] += "[]"
+			collectPaths(child, arrayPath, paths)
+		}
+	default:
+		paths[normalizePath(path)] = true
+	}
+}
+
+// normalizePath join the path segments and replace the dynamic keys with their pattern segment.
+func normalizePath(path []string) string {
+	segments := append([]string(nil), path...)
+	for _, pattern := range dynamicPaths {
+		patternSegments := strings.Split(pattern, ".")
+		if len(segments) < len(patternSegments) {
+			continue
+		}
+		matched := true
+		for i, p := range patternSegments {
+			if !matchSegment(p, segments[i]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			copy(segments, patternSegments)
+			break
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+func matchSegment(pattern string, segment string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(segment, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == segment
+}
@@ -0,0 +1,106 @@
+package activision
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func assertNoDrift(t *testing.T, fixture string, payload []byte) {
+	t.Helper()
+	newFields, removedFields, err := CompareWithFixture(fixture, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(newFields) > 0 || len(removedFields) > 0 {
+		t.Errorf("expected no drift in %s, got new fields %v and removed fields %v", fixture, newFields, removedFields)
+	}
+}
+
+func TestCompareWithFixtureOwnPayload(t *testing.T) {
+	for _, fixture := range []string{FixtureLastGames, FixtureLifetimeAndWeekly, FixtureGameByID} {
+		assertNoDrift(t, fixture, fixturePayload(t, fixture))
+	}
+}
+
+// The summary of the last games has a key for every mode that the player played in the last games, a player that did not
+// play one of the modes of the fixture is not drift.
+func TestCompareWithFixtureMissingSummaryMode(t *testing.T) {
+	var response map[string]interface{}
+	if err := json.Unmarshal(fixturePayload(t, FixtureLastGames), &response); err != nil {
+		t.Fatal(err)
+	}
+	summary := response["data"].(map[string]interface{})["summary"].(map[string]interface{})
+	if _, ok := summary["br_brduos"]; !ok {
+		t.Fatal("the fixture does not have br_brduos summary")
+	}
+	delete(summary, "br_brduos")
+	payload, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertNoDrift(t, FixtureLastGames, payload)
+}
+
+func TestRequiredPathsInFixtures(t *testing.T) {
+	for fixture, paths := range requiredPaths {
+		expected, err := loadFixturePaths(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range paths {
+			if !expected[p] {
+				t.Errorf("the required path %s is not in %s", p, fixture)
+			}
+		}
+	}
+}
+
+// lastGamesWith return the last games fixture after the change function changed every match.
+func lastGamesWith(t *testing.T, change func(matches []interface{}) []interface{}) []byte {
+	t.Helper()
+	var response map[string]interface{}
+	if err := json.Unmarshal(fixturePayload(t, FixtureLastGames), &response); err != nil {
+		t.Fatal(err)
+	}
+	data := response["data"].(map[string]interface{})
+	data["matches"] = change(data["matches"].([]interface{}))
+	payload, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func TestCompareWithFixtureRemovedFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(matches []interface{}) []interface{}
+		expected []string
+	}{
+		{"empty last page", func(matches []interface{}) []interface{} { return []interface{}{} }, nil},
+		{"optional stat is missing", func(matches []interface{}) []interface{} {
+			for _, m := range matches {
+				delete(m.(map[string]interface{})["playerStats"].(map[string]interface{}), "headshots")
+			}
+			return matches
+		}, nil},
+		{"required field is missing", func(matches []interface{}) []interface{} {
+			for _, m := range matches {
+				delete(m.(map[string]interface{}), "matchID")
+			}
+			return matches
+		}, []string{"data.matches[].matchID"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, removedFields, err := CompareWithFixture(FixtureLastGames, lastGamesWith(t, test.change))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(removedFields, test.expected) {
+				t.Errorf("expected removed fields %v, got %v", test.expected, removedFields)
+			}
+		})
+	}
+}
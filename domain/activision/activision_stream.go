@@ -0,0 +1,147 @@
+// This file contains the streaming decoding of the large responses, json.Decoder.Decode read the whole json value into his buffer
+// before he decode it, so for the full match response (150 players with their loadouts) we walk on the json tokens and decode
+// the players array element by element, that way the decoder buffer hold only one player at a time.
+
+package activision
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StreamDecoder is implemented by the response domain objects that know how to decode themselves from json.Decoder
+// without buffering the whole response.
+type StreamDecoder interface {
+	DecodeStream(d *json.Decoder) error
+}
+
+// DecodeStream decode the full match response and decode each player of the allPlayers array separately.
+func (r *SpecificGameStatsResponse) DecodeStream(d *json.Decoder) error {
+	return decodeObject(d, func(key string) error {
+		switch key {
+		case "status":
+			return d.Decode(&r.Status)
+		case "data":
+			return decodeObject(d, func(key string) error {
+				if key != "allPlayers" {
+					return skipValue(d)
+				}
+				return decodeArray(d, func() error {
+					var p PlayerGeneralStatsFromSpecificGame
+					if err := d.Decode(&p); err != nil {
+						return err
+					}
+					r.Data.AllPlayers = append(r.Data.AllPlayers, p)
+					return nil
+				})
+			})
+		default:
+			return skipValue(d)
+		}
+	})
+}
+
+// DecodeStream decode the last games response and decode each match of the matches array separately.
+func (r *LastGamesResponse) DecodeStream(d *json.Decoder) error {
+	return decodeObject(d, func(key string) error {
+		switch key {
+		case "status":
+			return d.Decode(&r.Status)
+		case "data":
+			return decodeObject(d, func(key string) error {
+				switch key {
+				case "summary":
+					return d.Decode(&r.Data.Summary)
+				case "matches":
+					return decodeArray(d, func() error {
+						var m Match
+						if err := d.Decode(&m); err != nil {
+							return err
+						}
+						r.Data.Matches = append(r.Data.Matches, m)
+						return nil
+					})
+				default:
+					return skipValue(d)
+				}
+			})
+		default:
+			return skipValue(d)
+		}
+	})
+}
+
+/***************************************** Help functions for walking on the json tokens *****************************************/
+
+// decodeObject read json object and call field for each key, field must consume the value of the key.
+// null is accepted and treated as empty object.
+func decodeObject(d *json.Decoder, field func(key string) error) error {
+	token, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected json object but found %v", token)
+	}
+	for d.More() {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("expected json object key but found %v", token)
+		}
+		if err := field(key); err != nil {
+			return err
+		}
+	}
+	_, err = d.Token() // the closing '}'
+	return err
+}
+
+// decodeArray read json array and call element for each element, element must consume the element value.
+// null is accepted and treated as empty array.
+func decodeArray(d *json.Decoder, element func() error) error {
+	token, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected json array but found %v", token)
+	}
+	for d.More() {
+		if err := element(); err != nil {
+			return err
+		}
+	}
+	_, err = d.Token() // the closing ']'
+	return err
+}
+
+// skipValue consume the next json value without decoding it.
+func skipValue(d *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := token.(json.Delim); ok {
+			if delim == '{' || delim == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package activision
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// fixturePayload return the saved response without the wrapper object of the fixture.
+func fixturePayload(tb testing.TB, fixture string) []byte {
+	tb.Helper()
+	payload, err := fixtures.ReadFile("OfficialResponsesFromActiApi/" + fixture)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if root, ok := fixtureRoots[fixture]; ok {
+		var wrapper map[string]json.RawMessage
+		if err := json.Unmarshal(payload, &wrapper); err != nil {
+			tb.Fatal(err)
+		}
+		payload = wrapper[root]
+	}
+	return payload
+}
+
+func TestDecodeStreamMatchesUnmarshal(t *testing.T) {
+	payload := fixturePayload(t, FixtureGameByID)
+	var expected, result SpecificGameStatsResponse
+	if err := json.Unmarshal(payload, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := result.DecodeStream(json.NewDecoder(bytes.NewReader(payload))); err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != expected.Status || len(result.Data.AllPlayers) != len(expected.Data.AllPlayers) {
+		t.Fatalf("expected status %q with %d players, got %q with %d players",
+			expected.Status, len(expected.Data.AllPlayers), result.Status, len(result.Data.AllPlayers))
+	}
+	for i := range expected.Data.AllPlayers {
+		if !reflect.DeepEqual(result.Data.AllPlayers[i], expected.Data.AllPlayers[i]) {
+			t.Fatalf("player %d is different from the json.Unmarshal result", i)
+		}
+	}
+}
+
+// BenchmarkDecodeReadAllUnmarshal is the decoding before the streaming, read all the body and then unmarshal it.
+func BenchmarkDecodeReadAllUnmarshal(b *testing.B) {
+	payload := fixturePayload(b, FixtureGameByID)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	for i := 0; i < b.N; i++ {
+		body, err := io.ReadAll(bytes.NewReader(payload))
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result SpecificGameStatsResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeStream decode the body while it is read, the players array is decoded one player at a time.
+func BenchmarkDecodeStream(b *testing.B) {
+	payload := fixturePayload(b, FixtureGameByID)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	for i := 0; i < b.N; i++ {
+		var result SpecificGameStatsResponse
+		if err := result.DecodeStream(json.NewDecoder(bytes.NewReader(payload))); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// This domain holds the awards (medals) that the player earned in a match, the official API return them under player.awards
+// as a map from the award key to the match time in milliseconds when the award was earned.
+package activision
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Awards is the map of the awards of the player from a single match, the key is the award key (for example "streak_5")
+// and the value is the match time in milliseconds when it was earned, the API return every award once per match.
+type Awards map[string]float64
+
+// Keys return the award keys sorted by the time they were earned.
+func (a Awards) Keys() []string {
+	keys := make([]string, 0, len(a))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if a[keys[i]] != a[keys[j]] {
+			return a[keys[i]] < a[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// EarnedAt return the time from the start of the match when the award was earned.
+func (a Awards) EarnedAt(key string) time.Duration {
+	return time.Duration(a[key]) * time.Millisecond
+}
+
+// AwardNames is the catalog of the friendly names of the awards that we saw in the official API responses.
+var AwardNames = map[string]string{
+	"air_kill":                    "Air Kill",
+	"air_to_air_kill":             "Air to Air Kill",
+	"assisted_suicide":            "Assisted Suicide",
+	"backstabber":                 "Backstabber",
+	"double":                      "Double Kill",
+	"triple":                      "Triple Kill",
+	"fury_kill":                   "Fury Kill",
+	"headshot":                    "Headshot",
+	"kill_jumper":                 "Kill a Jumping Enemy",
+	"longshot":                    "Longshot",
+	"low_health_kill":             "Survivor",
+	"mantle_kill":                 "Mantle Kill",
+	"mode_x_eliminate":            "Squad Wipe",
+	"one_shot_kill":               "One Shot Kill",
+	"one_shot_two_kills":          "Collateral",
+	"pointblank":                  "Point Blank",
+	"revenge":                     "Revenge",
+	"ss_kill_precision_airstrike": "Precision Airstrike Kill",
+	"ss_kill_toma_strike":         "Cluster Strike Kill",
+	"streak_5":                    "Killing Spree",
+	"streak_10":                   "Killing Frenzy",
+	"streak_15":                   "Mass Murder",
+	"streak_20":                   "Merciless",
+	"throwingknife_kill":          "Throwing Knife Kill",
+}
+
+// AwardName return the friendly name of the award, awards that are not in the catalog are named from their key ("streak_25" -> "Streak 25").
+func AwardName(key string) string {
+	if name, ok := AwardNames[key]; ok {
+		return name
+	}
+	words := strings.Fields(strings.ReplaceAll(key, "_", " "))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
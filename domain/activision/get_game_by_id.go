@@ -1,5 +1,7 @@
 package activision
 
+import "fmt"
+
 type PlayerFromGame struct {
 	UtcStartSeconds float64 `json:"utcStartSeconds"`
 	MatchID         string  `json:"matchID"`
@@ -20,7 +22,11 @@ type AllPlayersData struct {
 
 type PlayerGeneralStatsFromSpecificGame struct {
 	UtcStartSeconds float64                              `json:"utcStartSeconds"`
+	UtcEndSeconds   float64                              `json:"utcEndSeconds"`
+	PlayerCount     float64                              `json:"playerCount"`
 	MatchID         string                               `json:"matchID"`
+	Map             string                               `json:"map"`
+	Mode            string                               `json:"mode"`
 	PlayerStats     PlayerStatsFromSpecificGame          `json:"playerStats"`
 	Player          PlayerGeneralDetailsFromSpecificGame `json:"player"`
 }
@@ -43,10 +49,46 @@ type PlayerStatsFromSpecificGame struct {
 	TeamPlacement     float64 `json:"teamPlacement"`
 	DamageDone        float64 `json:"damageDone"`
 	DamageTaken       float64 `json:"damageTaken"`
+	TeamSurvivalTime  float64 `json:"teamSurvivalTime"` // in milliseconds, the same for all the team members
+
+	ObjectiveBrCacheOpen           float64    `json:"objectiveBrCacheOpen"`
+	ObjectiveBrKioskBuy            float64    `json:"objectiveBrKioskBuy"`
+	ObjectiveReviver               float64    `json:"objectiveReviver"`
+	ObjectiveBrMissionPickupTablet float64    `json:"objectiveBrMissionPickupTablet"`
+	ObjectiveTeamWiped             float64    `json:"objectiveTeamWiped"`
+	ObjectiveLastStandKill         float64    `json:"objectiveLastStandKill"`
+	Objectives                     Objectives `json:"objectives,omitempty"` // all the objectives counters, see objectives.go
+
+	MatchXp     float64 `json:"matchXp"`
+	ScoreXp     float64 `json:"scoreXp"`
+	MedalXp     float64 `json:"medalXp"`
+	ChallengeXp float64 `json:"challengeXp"`
+	BonusXp     float64 `json:"bonusXp"`
+	MiscXp      float64 `json:"miscXp"`
+	TotalXp     float64 `json:"totalXp"`
 }
 
 type PlayerGeneralDetailsFromSpecificGame struct {
-	Team     string `json:"team"`
-	Username string `json:"username"`
-	Uno      string `json:"uno"`
+	Team           string         `json:"team"`
+	Username       string         `json:"username"`
+	Uno            string         `json:"uno"`
+	Awards         Awards         `json:"awards"`
+	BrMissionStats BrMissionStats `json:"brMissionStats"`
+}
+
+// MissingRequiredFields return the fields that we can't work without and that are empty in the response.
+func (r SpecificGameStatsResponse) MissingRequiredFields() []string {
+	var missing []string
+	for i, p := range r.Data.AllPlayers {
+		if p.MatchID == "" {
+			missing = append(missing, fmt.Sprintf("data.allPlayers[%d].matchID", i))
+		}
+		if p.UtcStartSeconds == 0 {
+			missing = append(missing, fmt.Sprintf("data.allPlayers[%d].utcStartSeconds", i))
+		}
+		if p.Player.Uno == "" {
+			missing = append(missing, fmt.Sprintf("data.allPlayers[%d].player.uno", i))
+		}
+	}
+	return missing
 }
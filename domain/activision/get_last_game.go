@@ -1,6 +1,8 @@
 // This domain will be used to receive the response result from the 'get' last games stats activision endpoint
 package activision
 
+import "fmt"
+
 type LastGamesRequest struct {
 	Username string `json:"username"`
 	Platform string `json:"platform"`
@@ -66,10 +68,16 @@ type AllSummary struct {
 
 type Match struct {
 	UtcStartSeconds float64              `json:"utcStartSeconds"`
+	UtcEndSeconds   float64              `json:"utcEndSeconds"`
+	Duration        float64              `json:"duration"` // the match length in milliseconds
 	Mode            string               `json:"mode"`
+	Map             string               `json:"map"`
 	Gametype        string               `json:"gametype"`
 	MatchID         string               `json:"matchID"`
+	PlayerCount     float64              `json:"playerCount"`
 	PlayerStats     PlayerStatsFromMatch `json:"playerStats"`
+	// Player is the same details object that the match by ID response return for every player, here it is the requesting player.
+	Player PlayerGeneralDetailsFromSpecificGame `json:"player"`
 }
 
 type PlayerStatsFromMatch struct {
@@ -90,4 +98,27 @@ type PlayerStatsFromMatch struct {
 	DamageDone        float64 `json:"damageDone"`
 	DamageTaken       float64 `json:"damageTaken"`
 	TeamPlacement     float64 `json:"teamPlacement"`
+
+	MatchXp     float64 `json:"matchXp"`
+	ScoreXp     float64 `json:"scoreXp"`
+	MedalXp     float64 `json:"medalXp"`
+	ChallengeXp float64 `json:"challengeXp"`
+	BonusXp     float64 `json:"bonusXp"`
+	MiscXp      float64 `json:"miscXp"`
+	TotalXp     float64 `json:"totalXp"`
+}
+
+// MissingRequiredFields return the fields that we can't work without and that are empty in the response,
+// in case activision will change the response structure those fields will be decoded into zero values.
+func (r LastGamesResponse) MissingRequiredFields() []string {
+	var missing []string
+	for i, m := range r.Data.Matches {
+		if m.MatchID == "" {
+			missing = append(missing, fmt.Sprintf("data.matches[%d].matchID", i))
+		}
+		if m.UtcStartSeconds == 0 {
+			missing = append(missing, fmt.Sprintf("data.matches[%d].utcStartSeconds", i))
+		}
+	}
+	return missing
 }
@@ -33,10 +33,15 @@ type LifetimeAndWeeklyResponse struct {
 }
 
 type LifetimeAndWeeklyResponseData struct {
-	Platform string        `json:"platform"`
-	Username string        `json:"username"`
-	Lifetime LifetimeStats `json:"lifetime"`
-	Weekly   WeeklyStats   `json:"weekly"`
+	Platform         string        `json:"platform"`
+	Username         string        `json:"username"`
+	Level            float64       `json:"level"`
+	Prestige         float64       `json:"prestige"`
+	LevelXpRemainder float64       `json:"levelXpRemainder"` // the xp that is left until the next level
+	LevelXpGained    float64       `json:"levelXpGained"`    // the xp that was earned since the current level started
+	TotalXp          float64       `json:"totalXp"`
+	Lifetime         LifetimeStats `json:"lifetime"`
+	Weekly           WeeklyStats   `json:"weekly"`
 }
 
 type LifetimeStats struct {
@@ -99,3 +104,15 @@ type WeeklyStatsBrAllModeProperties struct {
 	Deaths             float64 `json:"deaths"`
 	DamageTaken        float64 `json:"damageTaken"`
 }
+
+// MissingRequiredFields return the fields that we can't work without and that are empty in the response.
+func (r LifetimeAndWeeklyResponse) MissingRequiredFields() []string {
+	var missing []string
+	if r.Data.Username == "" {
+		missing = append(missing, "data.username")
+	}
+	if r.Data.Platform == "" {
+		missing = append(missing, "data.platform")
+	}
+	return missing
+}
@@ -0,0 +1,22 @@
+// This domain holds the catalog of the map codes that the official API return in the 'map' field of the matches.
+package activision
+
+// MapNames translate the map code into the map name, some maps had few codes over the seasons.
+var MapNames = map[string]string{
+	"mp_don":       "Verdansk",
+	"mp_don3":      "Verdansk",
+	"mp_don4":      "Verdansk",
+	"mp_escape":    "Rebirth Island",
+	"mp_escape2":   "Rebirth Island",
+	"mp_escape3":   "Rebirth Island",
+	"mp_escape4":   "Rebirth Island",
+	"mp_wz_island": "Caldera",
+}
+
+// MapName return the name of the map code, unknown codes are returned as they are so new maps are still shown.
+func MapName(code string) string {
+	if name, ok := MapNames[code]; ok {
+		return name
+	}
+	return code
+}
@@ -0,0 +1,54 @@
+package activision
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMapName(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "mp_don", want: "Verdansk"},
+		{code: "mp_don4", want: "Verdansk"},
+		{code: "mp_escape4", want: "Rebirth Island"},
+		{code: "mp_wz_island", want: "Caldera"},
+		{code: "mp_wz_new_map", want: "mp_wz_new_map"},
+		{code: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := MapName(tt.code); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// Every map code of the saved responses must be in the catalog.
+func TestMapNamesCoverFixtures(t *testing.T) {
+	var lastGames LastGamesResponse
+	if err := json.Unmarshal(fixturePayload(t, FixtureLastGames), &lastGames); err != nil {
+		t.Fatal(err)
+	}
+	var game SpecificGameStatsResponse
+	if err := json.Unmarshal(fixturePayload(t, FixtureGameByID), &game); err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	for _, m := range lastGames.Data.Matches {
+		codes = append(codes, m.Map)
+	}
+	for _, p := range game.Data.AllPlayers {
+		codes = append(codes, p.Map)
+	}
+	if len(codes) == 0 {
+		t.Fatal("no matches in the fixtures")
+	}
+	for _, code := range codes {
+		if _, ok := MapNames[code]; !ok {
+			t.Errorf("map code %q of the fixtures is not in MapNames", code)
+		}
+	}
+}
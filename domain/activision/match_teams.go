@@ -0,0 +1,93 @@
+// This file reshape the full match response into teams, the official API return flat list of players
+// and every player holds the name of his team and the placement of the team.
+package activision
+
+import "sort"
+
+// MatchTeam is a single team of the match with its members and the sums of their stats.
+type MatchTeam struct {
+	Team         string                               `json:"team"`
+	Placement    float64                              `json:"placement"`
+	Kills        float64                              `json:"kills"`
+	Deaths       float64                              `json:"deaths"`
+	DamageDone   float64                              `json:"damageDone"`
+	DamageTaken  float64                              `json:"damageTaken"`
+	SurvivalTime float64                              `json:"survivalTime"` // the time in seconds from the start of the match until the team was eliminated
+	Members      []PlayerGeneralStatsFromSpecificGame `json:"members"`      // sorted by kills
+}
+
+// Won return true when the team finished in the first place.
+func (t MatchTeam) Won() bool {
+	return t.Placement == 1
+}
+
+// Teams group the players of the match by their team, the teams are sorted by placement from the winning team,
+// teams without placement (0, the match was not finished for them in the API data) are last.
+func (r SpecificGameStatsResponse) Teams() []MatchTeam {
+	byTeam := make(map[string]int)
+	var teams []MatchTeam
+	for _, p := range r.Data.AllPlayers {
+		i, ok := byTeam[p.Player.Team]
+		if !ok {
+			i = len(teams)
+			byTeam[p.Player.Team] = i
+			teams = append(teams, MatchTeam{Team: p.Player.Team, Placement: p.PlayerStats.TeamPlacement})
+		}
+		t := &teams[i]
+		t.Kills += p.PlayerStats.Kills
+		t.Deaths += p.PlayerStats.Deaths
+		t.DamageDone += p.PlayerStats.DamageDone
+		t.DamageTaken += p.PlayerStats.DamageTaken
+		if survival := survivalTime(p.PlayerStats); survival > t.SurvivalTime {
+			t.SurvivalTime = survival
+		}
+		if t.Placement == 0 {
+			t.Placement = p.PlayerStats.TeamPlacement
+		}
+		t.Members = append(t.Members, p)
+	}
+	for i := range teams {
+		members := teams[i].Members
+		sort.SliceStable(members, func(a, b int) bool { return members[a].PlayerStats.Kills > members[b].PlayerStats.Kills })
+	}
+	sort.SliceStable(teams, func(i, j int) bool {
+		if (teams[i].Placement == 0) != (teams[j].Placement == 0) {
+			return teams[j].Placement == 0
+		}
+		return teams[i].Placement < teams[j].Placement
+	})
+	return teams
+}
+
+// survivalTime return the survival time of the player team in seconds, the official API return it in milliseconds.
+// Responses without teamSurvivalTime use the time played of the player.
+func survivalTime(s PlayerStatsFromSpecificGame) float64 {
+	if s.TeamSurvivalTime > 0 {
+		return s.TeamSurvivalTime / 1000
+	}
+	return s.TimePlayed
+}
+
+// WinningTeam return the team that finished in the first place, the second return value is false when no team has the first place.
+func (r SpecificGameStatsResponse) WinningTeam() (MatchTeam, bool) {
+	teams := r.Teams()
+	if len(teams) == 0 || !teams[0].Won() {
+		return MatchTeam{}, false
+	}
+	return teams[0], true
+}
+
+// TeamOf return the team of the player with the given Uno ID, the second return value is false when the player not found.
+func (r SpecificGameStatsResponse) TeamOf(uno string) (MatchTeam, bool) {
+	for _, p := range r.Data.AllPlayers {
+		if p.Player.Uno != uno {
+			continue
+		}
+		for _, t := range r.Teams() {
+			if t.Team == p.Player.Team {
+				return t, true
+			}
+		}
+	}
+	return MatchTeam{}, false
+}
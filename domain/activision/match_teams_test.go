@@ -0,0 +1,28 @@
+package activision
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTeamsSurvivalTime(t *testing.T) {
+	var response SpecificGameStatsResponse
+	if err := json.Unmarshal(fixturePayload(t, FixtureGameByID), &response); err != nil {
+		t.Fatal(err)
+	}
+	for _, team := range response.Teams() {
+		expected := team.Members[0].PlayerStats.TeamSurvivalTime / 1000
+		if team.SurvivalTime != expected {
+			t.Fatalf("expected team %s survival time %v seconds, got %v", team.Team, expected, team.SurvivalTime)
+		}
+	}
+	winner, ok := response.WinningTeam()
+	if !ok {
+		t.Fatal("expected winning team")
+	}
+	for _, team := range response.Teams()[1:] {
+		if team.SurvivalTime > winner.SurvivalTime {
+			t.Errorf("team %s survived %v seconds, more than the winning team %v", team.Team, team.SurvivalTime, winner.SurvivalTime)
+		}
+	}
+}
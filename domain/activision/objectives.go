@@ -0,0 +1,81 @@
+// This domain holds the battle royale missions and the objectives counters of the player from a full match response,
+// the official API write every objective counter as a separate "objective*" field of playerStats and only the counters
+// that are above 0 are returned, so the counters are decoded into a dynamic map in addition to the typed common ones.
+package activision
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// objectivePrefix is the prefix of all the objectives counters fields.
+const objectivePrefix = "objective"
+
+// The keys of the common objectives counters.
+const (
+	ObjectiveCacheOpen     = "objectiveBrCacheOpen"
+	ObjectiveKioskBuy      = "objectiveBrKioskBuy"
+	ObjectiveReviver       = "objectiveReviver"
+	ObjectiveTabletPickup  = "objectiveBrMissionPickupTablet"
+	ObjectiveTeamWiped     = "objectiveTeamWiped"
+	ObjectiveLastStandKill = "objectiveLastStandKill"
+)
+
+// Objectives is the map of all the objectives counters of the player from a single match by the field name.
+type Objectives map[string]float64
+
+// BrMissionStats is the contracts (missions) summary of the player from a single match.
+type BrMissionStats struct {
+	MissionsComplete           float64                     `json:"missionsComplete"`
+	TotalMissionXpEarned       float64                     `json:"totalMissionXpEarned"`
+	TotalMissionWeaponXpEarned float64                     `json:"totalMissionWeaponXpEarned"`
+	MissionStatsByType         map[string]MissionTypeStats `json:"missionStatsByType"` // by mission type, for example "assassination"
+}
+
+type MissionTypeStats struct {
+	Xp       float64 `json:"xp"`
+	WeaponXp float64 `json:"weaponXp"`
+	Count    float64 `json:"count"`
+}
+
+// playerStatsFields is the index of the PlayerStatsFromSpecificGame fields by their json name.
+var playerStatsFields = func() map[string]int {
+	t := reflect.TypeOf(PlayerStatsFromSpecificGame{})
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		fields[name] = i
+	}
+	return fields
+}()
+
+// UnmarshalJSON decode the stats in a single pass over the fields, every field is set into the struct by its json
+// name and all the "objective*" fields are collected into the Objectives map as well.
+func (s *PlayerStatsFromSpecificGame) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(s).Elem()
+	for key, raw := range fields {
+		if i, ok := playerStatsFields[key]; ok {
+			if err := json.Unmarshal(raw, value.Field(i).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+		if !strings.HasPrefix(key, objectivePrefix) {
+			continue
+		}
+		var counter float64
+		// The "objectives" field of our own json output is an object and not a counter, it is already decoded above.
+		if err := json.Unmarshal(raw, &counter); err != nil {
+			continue
+		}
+		if s.Objectives == nil {
+			s.Objectives = make(Objectives)
+		}
+		s.Objectives[key] = counter
+	}
+	return nil
+}
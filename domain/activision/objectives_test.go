@@ -0,0 +1,69 @@
+package activision
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlayerStatsObjectives(t *testing.T) {
+	var response SpecificGameStatsResponse
+	if err := json.Unmarshal(fixturePayload(t, FixtureGameByID), &response); err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		Data struct {
+			AllPlayers []struct {
+				PlayerStats map[string]json.RawMessage `json:"playerStats"`
+			} `json:"allPlayers"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(fixturePayload(t, FixtureGameByID), &raw); err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range response.Data.AllPlayers {
+		stats := raw.Data.AllPlayers[i].PlayerStats
+		// The typed fields must be the same as the usual decoding of the struct.
+		type plain PlayerStatsFromSpecificGame
+		var expected plain
+		data, _ := json.Marshal(stats)
+		if err := json.Unmarshal(data, &expected); err != nil {
+			t.Fatal(err)
+		}
+		actual := plain(p.PlayerStats)
+		actual.Objectives = nil
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("player %d: expected %+v, got %+v", i, expected, actual)
+		}
+		objectives := 0
+		for key := range stats {
+			if strings.HasPrefix(key, objectivePrefix) {
+				objectives++
+				if _, ok := p.PlayerStats.Objectives[key]; !ok {
+					t.Errorf("player %d: %s is missing from the objectives", i, key)
+				}
+			}
+		}
+		if len(p.PlayerStats.Objectives) != objectives {
+			t.Errorf("player %d: expected %d objectives, got %d", i, objectives, len(p.PlayerStats.Objectives))
+		}
+	}
+
+	// Our own json output has the "objectives" object, decoding it again must return the same stats.
+	first := response.Data.AllPlayers[0].PlayerStats
+	data, err := json.Marshal(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded PlayerStatsFromSpecificGame
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, first) {
+		t.Errorf("expected %+v after the round trip, got %+v", first, decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"kills": "many"}`), &decoded); err == nil {
+		t.Error("expected error for a field with a wrong type")
+	}
+}
@@ -0,0 +1,127 @@
+// This file is responsible for the query complexity limit, a single query can ask for the full lobby of many matches
+// and every match is a request to the official API, so every root field estimate the cost of its selection before it
+// fetch anything and the query is rejected when the total cost is above the limit.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/ast"
+)
+
+// DefaultMaxComplexity is the complexity limit when SetMaxComplexity was not called, the recent 20 matches of a player
+// with the full lobby and 10 stats of every player cost about 35000.
+const DefaultMaxComplexity = 50000
+
+// defaultListSize is the expected size of a list field that has no 'first' argument and is not in listSizes.
+const defaultListSize = 20
+
+// listSizes is the expected size of the list fields when the query does not set the 'first' argument.
+var listSizes = map[string]int{
+	"Player.recentMatches": 20,
+	"Match.players":        150,
+	"Match.teams":          50,
+	"Team.members":         4,
+	"Squad.players":        4,
+	"Squad.matches":        20,
+}
+
+var maxComplexity = DefaultMaxComplexity
+
+// SetMaxComplexity change the complexity limit of the queries.
+func SetMaxComplexity(n int) {
+	maxComplexity = n
+}
+
+type complexityKey struct{}
+
+// withComplexity return context with the complexity counter of a new query.
+func withComplexity(ctx context.Context) context.Context {
+	return context.WithValue(ctx, complexityKey{}, new(atomic.Int64))
+}
+
+// chargeComplexity add the cost of the current root field to the query complexity and return error when the total is
+// above the limit, it must be called by the root resolvers before they fetch anything. The root fields of the query
+// run in parallel so a root field that was charged before the limit was reached may still run.
+func chargeComplexity(ctx context.Context, typeName string) error {
+	cost := 1 + selectionComplexity(ctx, typeName, "", graphql.SelectedFieldNames(ctx))
+	total := int(cost)
+	if counter, ok := ctx.Value(complexityKey{}).(*atomic.Int64); ok {
+		total = int(counter.Add(int64(cost)))
+	}
+	if total > maxComplexity {
+		return fmt.Errorf("query complexity %d is above the limit %d", total, maxComplexity)
+	}
+	return nil
+}
+
+// selectionComplexity return the estimated cost of the fields under the path, every field cost 1 and the cost of the
+// fields inside a list is multiplied by the expected size of the list.
+// The paths are the selected fields paths of graphql-go, aliases of the same field are counted once.
+func selectionComplexity(ctx context.Context, typeName string, path string, paths []string) int {
+	object, ok := schema.AST().Types[typeName].(*ast.ObjectTypeDefinition)
+	if !ok {
+		return 0
+	}
+	prefix := path
+	if prefix != "" {
+		prefix += "."
+	}
+	total := 0
+	for _, p := range paths {
+		name, ok := strings.CutPrefix(p, prefix)
+		if !ok || strings.Contains(name, ".") {
+			continue
+		}
+		field := object.Fields.Get(name)
+		if field == nil {
+			continue
+		}
+		elem, isList := unwrapType(field.Type)
+		cost := 1 + selectionComplexity(ctx, elem, p, paths)
+		if isList {
+			cost *= listSize(ctx, typeName+"."+name, p)
+		}
+		total += cost
+	}
+	return total
+}
+
+// unwrapType return the name of the named type inside the non null and list wrappers and true when it is a list.
+func unwrapType(t ast.Type) (string, bool) {
+	isList := false
+	for {
+		switch w := t.(type) {
+		case *ast.NonNull:
+			t = w.OfType
+		case *ast.List:
+			isList = true
+			t = w.OfType
+		case ast.NamedType:
+			return w.TypeName(), isList
+		default:
+			return "", isList
+		}
+	}
+}
+
+// listSize return the 'first' argument of the field or the expected size of the list, at least 1 so an invalid
+// argument will not make the query cheaper (the resolvers reject it anyway).
+func listSize(ctx context.Context, field string, path string) int {
+	var args struct{ First *int32 }
+	if ok, err := graphql.DecodeSelectedFieldArgs(ctx, path, &args); ok && err == nil && args.First != nil {
+		if *args.First < 1 {
+			return 1
+		}
+		return int(*args.First)
+	}
+	if size, ok := listSizes[field]; ok {
+		return size
+	}
+	return defaultListSize
+}
@@ -0,0 +1,33 @@
+// This file is responsible for running the queries, the schema is parsed once with the resolvers and every query
+// runs with its own match loader and complexity counter.
+
+package graph
+
+import (
+	"context"
+
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// MaxDepth is the maximum nesting of the fields in a query.
+const MaxDepth = 10
+
+// maxParallelism is the number of resolvers of a single query that run at the same time, the requests to the official API
+// are limited by the rate limiter of the providers anyway.
+const maxParallelism = 10
+
+var schema = graphql.MustParseSchema(schemaString, &resolver{},
+	graphql.UseFieldResolvers(), graphql.MaxDepth(MaxDepth), graphql.MaxParallelism(maxParallelism))
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Execute run the query with a new match loader and complexity counter, the errors are returned inside the response
+// as the GraphQL spec define. The complexity is checked by the root resolvers (see complexity.go).
+func Execute(ctx context.Context, r Request) *graphql.Response {
+	return schema.Exec(withComplexity(withMatchLoader(ctx)), r.Query, r.OperationName, r.Variables)
+}
@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// stubFullMatches replace fetchFullMatches with the match fixture for every ID and return the batches that was fetched.
+func stubFullMatches(t *testing.T) (*activision.SpecificGameStatsResponse, *[][]string) {
+	t.Helper()
+	data, err := os.ReadFile("../domain/activision/OfficialResponsesFromActiApi/successResponseFromMatchID.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture struct {
+		Data activision.SpecificGameStatsResponse `json:"data"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	var batches [][]string
+	old := fetchFullMatches
+	t.Cleanup(func() { fetchFullMatches = old })
+	fetchFullMatches = func(ids []string) ([]*activision.SpecificGameStatsResponse, []error) {
+		batches = append(batches, ids)
+		fulls := make([]*activision.SpecificGameStatsResponse, len(ids))
+		for i := range ids {
+			fulls[i] = &fixture.Data
+		}
+		return fulls, make([]error, len(ids))
+	}
+	return &fixture.Data, &batches
+}
+
+func TestMatchByIDSummary(t *testing.T) {
+	fixture, _ := stubFullMatches(t)
+	first := fixture.Data.AllPlayers[0]
+
+	response := Execute(context.Background(), Request{
+		Query:     `query($id: ID!) { match(id: $id) { mode utcStartSeconds utcEndSeconds playerCount } }`,
+		Variables: map[string]interface{}{"id": first.MatchID},
+	})
+	if len(response.Errors) > 0 {
+		t.Fatal(response.Errors)
+	}
+	var result struct {
+		Match struct {
+			Mode            string  `json:"mode"`
+			UtcStartSeconds float64 `json:"utcStartSeconds"`
+			UtcEndSeconds   float64 `json:"utcEndSeconds"`
+			PlayerCount     float64 `json:"playerCount"`
+		} `json:"match"`
+	}
+	if err := json.Unmarshal(response.Data, &result); err != nil {
+		t.Fatal(err)
+	}
+	m := result.Match
+	if m.Mode != first.Mode || m.UtcStartSeconds != first.UtcStartSeconds || m.UtcEndSeconds != first.UtcEndSeconds || m.PlayerCount != first.PlayerCount {
+		t.Errorf("expected the summary of the lobby %+v, got %+v", first, m)
+	}
+}
+
+func TestMatchLoaderBatch(t *testing.T) {
+	_, batches := stubFullMatches(t)
+	response := Execute(context.Background(), Request{
+		Query: `{ a: match(id: "1") { mode } b: match(id: "2") { mode } c: match(id: "1") { mapName } }`,
+	})
+	if len(response.Errors) > 0 {
+		t.Fatal(response.Errors)
+	}
+	if len(*batches) != 1 {
+		t.Fatalf("expected the matches to be fetched in 1 batch, got %v", *batches)
+	}
+	ids := (*batches)[0]
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != "1,2" {
+		t.Errorf("expected every match to be fetched once, got %v", ids)
+	}
+}
+
+func TestQueryComplexity(t *testing.T) {
+	_, batches := stubFullMatches(t)
+	defer SetMaxComplexity(DefaultMaxComplexity)
+	// 1 for the match and 150 expected players, every player cost 1 for the players field and 2 for its fields.
+	query := `{ match(id: "1") { players { uno username } } }`
+	SetMaxComplexity(1 + 150*3)
+	if response := Execute(context.Background(), Request{Query: query}); len(response.Errors) > 0 {
+		t.Fatalf("expected the query to be in the limit, got %v", response.Errors)
+	}
+
+	SetMaxComplexity(100)
+	response := Execute(context.Background(), Request{Query: query})
+	if len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, "query complexity 451 is above the limit 100") {
+		t.Fatalf("expected complexity error, got %v", response.Errors)
+	}
+	if len(*batches) != 1 {
+		t.Errorf("the rejected query must not fetch the match, got %d batches", len(*batches))
+	}
+	response = Execute(context.Background(), Request{Query: `{ match(id: "1") { players(first: 10) { uno username } } }`})
+	if len(response.Errors) > 0 {
+		t.Errorf("expected the 'first' argument to limit the cost, got %v", response.Errors)
+	}
+	response = Execute(context.Background(), Request{
+		Query:     `query($first: Int) { match(id: "1") { players(first: $first) { uno username } } }`,
+		Variables: map[string]interface{}{"first": 10},
+	})
+	if len(response.Errors) > 0 {
+		t.Errorf("expected the 'first' variable to limit the cost, got %v", response.Errors)
+	}
+}
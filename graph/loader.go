@@ -0,0 +1,91 @@
+// This file is responsible for the batched loading of the full matches, the resolvers of the same query run in parallel
+// so instead of sending a request for every match they ask the loader, the loader wait a short time and fetch all the
+// requested matches together with services.GetFullMatches.
+
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/services"
+)
+
+// loaderWait is the time that the loader wait for more keys before it fetch the batch.
+const loaderWait = 10 * time.Millisecond
+
+type loaderKey struct{}
+
+// fetchFullMatches is the function that fetch the batch, it is services.GetFullMatches unless changed.
+var fetchFullMatches = services.GetFullMatches
+
+// matchLoader collect the match IDs that are requested during loaderWait and fetch them together,
+// every match is fetched once and all the resolvers that asked for it receive the same result.
+type matchLoader struct {
+	mutex   sync.Mutex
+	matches map[string]*loadedMatch
+	pending []string
+}
+
+// loadedMatch is the result of a single match, done is closed when the batch of the match was fetched.
+type loadedMatch struct {
+	done  chan struct{}
+	match *activision.SpecificGameStatsResponse
+	err   error
+}
+
+// withMatchLoader return context with a new match loader, every query get its own loader so the results are not shared
+// between the requests (the sharing is done by the cache middleware of the providers).
+func withMatchLoader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loaderKey{}, &matchLoader{matches: make(map[string]*loadedMatch)})
+}
+
+// loadMatch return the full match from the loader of the context.
+func loadMatch(ctx context.Context, matchID string) (*activision.SpecificGameStatsResponse, error) {
+	loader, ok := ctx.Value(loaderKey{}).(*matchLoader)
+	if !ok {
+		ctx = withMatchLoader(ctx)
+		loader = ctx.Value(loaderKey{}).(*matchLoader)
+	}
+	return loader.load(ctx, matchID)
+}
+
+func (l *matchLoader) load(ctx context.Context, matchID string) (*activision.SpecificGameStatsResponse, error) {
+	l.mutex.Lock()
+	loaded, ok := l.matches[matchID]
+	if !ok {
+		loaded = &loadedMatch{done: make(chan struct{})}
+		l.matches[matchID] = loaded
+		l.pending = append(l.pending, matchID)
+		// The first key of the batch start the timer, the keys that come until it fires join the same batch.
+		if len(l.pending) == 1 {
+			time.AfterFunc(loaderWait, l.fetch)
+		}
+	}
+	l.mutex.Unlock()
+	select {
+	case <-loaded.done:
+		return loaded.match, loaded.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch fetch the pending batch, the results of fetchFullMatches are in the same order of the IDs.
+func (l *matchLoader) fetch() {
+	l.mutex.Lock()
+	ids := l.pending
+	l.pending = nil
+	batch := make([]*loadedMatch, len(ids))
+	for i, id := range ids {
+		batch[i] = l.matches[id]
+	}
+	l.mutex.Unlock()
+	fulls, errs := fetchFullMatches(ids)
+	for i, loaded := range batch {
+		loaded.match, loaded.err = fulls[i], errs[i]
+		close(loaded.done)
+	}
+}
@@ -0,0 +1,449 @@
+// This file contains the resolvers of the schema types, the player profile and the recent matches are fetched once
+// per player and the full matches are fetched through the match loader.
+
+package graph
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+	"github.com/NivNagli/WarzoneSquad_Go/services"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// maxRecentMatches is the maximum 'first' of the recent matches, more than 20 games are fetched in cycles of 20.
+const maxRecentMatches = 100
+
+// resolver is the root resolver of the Query type.
+type resolver struct{}
+
+func (r *resolver) Player(ctx context.Context, args struct{ Platform, Username string }) (*playerResolver, error) {
+	if err := chargeComplexity(ctx, "Player"); err != nil {
+		return nil, err
+	}
+	if args.Platform == "" || args.Username == "" {
+		return nil, activision.NewValidationError("player", args.Platform+":"+args.Username, "platform and username are required")
+	}
+	return newPlayerResolver(activision.LastGamesRequest{Platform: args.Platform, Username: args.Username}), nil
+}
+
+func (r *resolver) Match(ctx context.Context, args struct{ ID graphql.ID }) (*matchResolver, error) {
+	if err := chargeComplexity(ctx, "Match"); err != nil {
+		return nil, err
+	}
+	id := string(args.ID)
+	if id == "" {
+		return nil, activision.NewValidationError("id", id, "match id is required")
+	}
+	if _, err := loadMatch(ctx, id); err != nil {
+		return nil, err
+	}
+	return &matchResolver{id: id}, nil
+}
+
+func (r *resolver) Squad(ctx context.Context, args struct{ Players []string }) (*squadResolver, error) {
+	if err := chargeComplexity(ctx, "Squad"); err != nil {
+		return nil, err
+	}
+	if len(args.Players) == 0 {
+		return nil, activision.NewValidationError("players", "", "at least one player is required")
+	}
+	squad := &squadResolver{}
+	for _, s := range args.Players {
+		player, err := services.ParsePlayer(s)
+		if err != nil {
+			return nil, err
+		}
+		squad.players = append(squad.players, newPlayerResolver(player))
+	}
+	return squad, nil
+}
+
+/*************************************************** Player ***************************************************/
+
+// playerResolver fetch the profile and the last 20 games of the player only once, even when many fields need them.
+type playerResolver struct {
+	request activision.LastGamesRequest
+
+	profileOnce sync.Once
+	profile     *activision.LifetimeAndWeeklyResponse
+	profileErr  error
+
+	recentOnce sync.Once
+	recent     *activision.LastGamesResponse
+	recentErr  error
+}
+
+func newPlayerResolver(request activision.LastGamesRequest) *playerResolver {
+	return &playerResolver{request: request}
+}
+
+func (r *playerResolver) getProfile() (*activision.LifetimeAndWeeklyResponse, error) {
+	r.profileOnce.Do(func() {
+		r.profile, r.profileErr = activision_providers.GetLifetimeAndWeeklyStats(activision.LifetimeAndWeeklyRequest{Username: r.request.Username, Platform: r.request.Platform})
+	})
+	return r.profile, r.profileErr
+}
+
+// getRecent return the 'games' most recent matches, the last 20 games are fetched once and more games are fetched on every call.
+func (r *playerResolver) getRecent(games int) ([]activision.Match, error) {
+	if games > 20 {
+		result, err := services.GetRecentMatches(r.request, games)
+		if err != nil {
+			return nil, err
+		}
+		return result.Data.Matches, nil
+	}
+	r.recentOnce.Do(func() {
+		r.recent, r.recentErr = services.GetRecentMatches(r.request, 20)
+	})
+	if r.recentErr != nil {
+		return nil, r.recentErr
+	}
+	matches := r.recent.Data.Matches
+	if len(matches) > games {
+		matches = matches[:games]
+	}
+	return matches, nil
+}
+
+func (r *playerResolver) Username() string {
+	return r.request.Username
+}
+
+func (r *playerResolver) Platform() string {
+	return r.request.Platform
+}
+
+func (r *playerResolver) Level() (float64, error) {
+	profile, err := r.getProfile()
+	if err != nil {
+		return 0, err
+	}
+	return profile.Data.Level, nil
+}
+
+func (r *playerResolver) Prestige() (float64, error) {
+	profile, err := r.getProfile()
+	if err != nil {
+		return 0, err
+	}
+	return profile.Data.Prestige, nil
+}
+
+func (r *playerResolver) TotalXp() (float64, error) {
+	profile, err := r.getProfile()
+	if err != nil {
+		return 0, err
+	}
+	return profile.Data.TotalXp, nil
+}
+
+func (r *playerResolver) LevelXpRemainder() (float64, error) {
+	profile, err := r.getProfile()
+	if err != nil {
+		return 0, err
+	}
+	return profile.Data.LevelXpRemainder, nil
+}
+
+func (r *playerResolver) Lifetime() (*activision.LifetimeStatsBrModeProperties, error) {
+	profile, err := r.getProfile()
+	if err != nil {
+		return nil, err
+	}
+	return &profile.Data.Lifetime.Mode.BattleRoyal.Properties, nil
+}
+
+func (r *playerResolver) Weekly() (*activision.WeeklyStatsBrAllModeProperties, error) {
+	profile, err := r.getProfile()
+	if err != nil {
+		return nil, err
+	}
+	return &profile.Data.Weekly.Mode.BattleRoyalAll.Properties, nil
+}
+
+func (r *playerResolver) RecentMatches(args struct{ First *int32 }) ([]*matchResolver, error) {
+	games, err := first(args.First, 20, maxRecentMatches)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := r.getRecent(games)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*matchResolver, len(matches))
+	for i := range matches {
+		result[i] = &matchResolver{id: matches[i].MatchID, match: &matches[i]}
+	}
+	return result, nil
+}
+
+/*************************************************** Match ***************************************************/
+
+// matchResolver resolve the fields of the match from the recent matches response when we have it,
+// the lobby fields and the matches that were requested by ID use the full match from the loader.
+type matchResolver struct {
+	id    string
+	match *activision.Match // nil when the match was requested by ID
+}
+
+func (r *matchResolver) full(ctx context.Context) (*activision.SpecificGameStatsResponse, error) {
+	return loadMatch(ctx, r.id)
+}
+
+// summary return the general fields of the match from the recent matches response or from the first player of the lobby.
+func (r *matchResolver) summary(ctx context.Context) (activision.Match, error) {
+	if r.match != nil {
+		return *r.match, nil
+	}
+	full, err := r.full(ctx)
+	if err != nil {
+		return activision.Match{}, err
+	}
+	m := activision.Match{MatchID: r.id}
+	if len(full.Data.AllPlayers) > 0 {
+		first := full.Data.AllPlayers[0]
+		m.Mode, m.Map, m.PlayerCount = first.Mode, first.Map, first.PlayerCount
+		m.UtcStartSeconds, m.UtcEndSeconds = first.UtcStartSeconds, first.UtcEndSeconds
+	}
+	return m, nil
+}
+
+func (r *matchResolver) ID() graphql.ID {
+	return graphql.ID(r.id)
+}
+
+func (r *matchResolver) Mode(ctx context.Context) (string, error) {
+	m, err := r.summary(ctx)
+	return m.Mode, err
+}
+
+func (r *matchResolver) Map(ctx context.Context) (string, error) {
+	m, err := r.summary(ctx)
+	return m.Map, err
+}
+
+func (r *matchResolver) MapName(ctx context.Context) (string, error) {
+	m, err := r.summary(ctx)
+	return activision.MapName(m.Map), err
+}
+
+func (r *matchResolver) UtcStartSeconds(ctx context.Context) (float64, error) {
+	m, err := r.summary(ctx)
+	return m.UtcStartSeconds, err
+}
+
+func (r *matchResolver) UtcEndSeconds(ctx context.Context) (float64, error) {
+	m, err := r.summary(ctx)
+	return m.UtcEndSeconds, err
+}
+
+func (r *matchResolver) PlayerCount(ctx context.Context) (float64, error) {
+	m, err := r.summary(ctx)
+	return m.PlayerCount, err
+}
+
+func (r *matchResolver) Stats() *activision.PlayerStatsFromMatch {
+	if r.match == nil {
+		return nil
+	}
+	return &r.match.PlayerStats
+}
+
+func (r *matchResolver) Players(ctx context.Context, args struct{ First *int32 }) ([]*matchPlayerResolver, error) {
+	full, err := r.full(ctx)
+	if err != nil {
+		return nil, err
+	}
+	players := make([]activision.PlayerGeneralStatsFromSpecificGame, len(full.Data.AllPlayers))
+	copy(players, full.Data.AllPlayers)
+	limit, err := first(args.First, len(players), math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(players, func(i, j int) bool { return players[i].PlayerStats.Kills > players[j].PlayerStats.Kills })
+	if limit < len(players) {
+		players = players[:limit]
+	}
+	return newMatchPlayers(players), nil
+}
+
+func (r *matchResolver) Teams(ctx context.Context, args struct{ First *int32 }) ([]*teamResolver, error) {
+	full, err := r.full(ctx)
+	if err != nil {
+		return nil, err
+	}
+	teams := full.Teams()
+	limit, err := first(args.First, len(teams), math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	if limit < len(teams) {
+		teams = teams[:limit]
+	}
+	result := make([]*teamResolver, len(teams))
+	for i, team := range teams {
+		result[i] = &teamResolver{team: team}
+	}
+	return result, nil
+}
+
+func (r *matchResolver) WinningTeam(ctx context.Context) (*teamResolver, error) {
+	full, err := r.full(ctx)
+	if err != nil {
+		return nil, err
+	}
+	team, ok := full.WinningTeam()
+	if !ok {
+		return nil, nil
+	}
+	return &teamResolver{team: team}, nil
+}
+
+/*************************************************** Lobby ***************************************************/
+
+type matchPlayerResolver struct {
+	player activision.PlayerGeneralStatsFromSpecificGame
+}
+
+func newMatchPlayers(players []activision.PlayerGeneralStatsFromSpecificGame) []*matchPlayerResolver {
+	result := make([]*matchPlayerResolver, len(players))
+	for i, p := range players {
+		result[i] = &matchPlayerResolver{player: p}
+	}
+	return result
+}
+
+func (r *matchPlayerResolver) Uno() string {
+	return r.player.Player.Uno
+}
+
+func (r *matchPlayerResolver) Username() string {
+	return r.player.Player.Username
+}
+
+func (r *matchPlayerResolver) Team() string {
+	return r.player.Player.Team
+}
+
+func (r *matchPlayerResolver) Stats() *activision.PlayerStatsFromSpecificGame {
+	return &r.player.PlayerStats
+}
+
+type teamResolver struct {
+	team activision.MatchTeam
+}
+
+func (r *teamResolver) Name() string {
+	return r.team.Team
+}
+
+func (r *teamResolver) Placement() float64 {
+	return r.team.Placement
+}
+
+func (r *teamResolver) Kills() float64 {
+	return r.team.Kills
+}
+
+func (r *teamResolver) Deaths() float64 {
+	return r.team.Deaths
+}
+
+func (r *teamResolver) DamageDone() float64 {
+	return r.team.DamageDone
+}
+
+func (r *teamResolver) SurvivalTime() float64 {
+	return r.team.SurvivalTime
+}
+
+func (r *teamResolver) Members() []*matchPlayerResolver {
+	return newMatchPlayers(r.team.Members)
+}
+
+/*************************************************** Squad ***************************************************/
+
+type squadResolver struct {
+	players []*playerResolver
+}
+
+func (r *squadResolver) Players() []*playerResolver {
+	return r.players
+}
+
+// Matches return the recent matches that at least two squad members played together, newest first, the match stats
+// are of the first squad member that played it. Members that we failed to fetch are skipped unless all of them failed.
+func (r *squadResolver) Matches(args struct{ First *int32 }) ([]*matchResolver, error) {
+	limit, err := first(args.First, 20, maxRecentMatches)
+	if err != nil {
+		return nil, err
+	}
+	recent := make([][]activision.Match, len(r.players))
+	errs := make([]error, len(r.players))
+	var wg sync.WaitGroup
+	for i, p := range r.players {
+		wg.Add(1)
+		go func(i int, p *playerResolver) {
+			defer wg.Done()
+			recent[i], errs[i] = p.getRecent(20)
+		}(i, p)
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	matches := make(map[string]*activision.Match)
+	var order []*activision.Match
+	failed := 0
+	for i := range r.players {
+		if errs[i] != nil {
+			failed++
+			continue
+		}
+		for j := range recent[i] {
+			m := &recent[i][j]
+			counts[m.MatchID]++
+			if _, ok := matches[m.MatchID]; !ok {
+				matches[m.MatchID] = m
+				order = append(order, m)
+			}
+		}
+	}
+	if failed == len(r.players) {
+		return nil, errs[0]
+	}
+	sort.SliceStable(order, func(i, j int) bool { return order[i].UtcStartSeconds > order[j].UtcStartSeconds })
+	var result []*matchResolver
+	for _, m := range order {
+		if counts[m.MatchID] < 2 {
+			continue
+		}
+		result = append(result, &matchResolver{id: m.MatchID, match: m})
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// first return the value of the 'first' argument, the default value when it is not set.
+// A value above the length of a list is allowed and return the whole list, so maxValue is only the limit of the fetched lists.
+func first(arg *int32, defaultValue int, maxValue int) (int, error) {
+	if arg == nil {
+		return defaultValue, nil
+	}
+	value := int(*arg)
+	if value < 1 {
+		return 0, activision.NewValidationError("first", strconv.Itoa(value), "first must be at least 1")
+	}
+	if value > maxValue {
+		return 0, activision.NewValidationError("first", strconv.Itoa(value), "first must be at most "+strconv.Itoa(maxValue))
+	}
+	return value, nil
+}
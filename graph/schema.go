@@ -0,0 +1,118 @@
+// Package graph implements the GraphQL endpoint of WarzoneSquad, the resolvers use the same providers and services
+// as the http controllers, the full matches are loaded in batches (see loader.go) and every query is checked against
+// a complexity limit before it runs (see complexity.go).
+
+package graph
+
+// schemaString is the GraphQL schema, the times are unix seconds and the stats are floats like in the official API responses.
+const schemaString = `
+schema {
+	query: Query
+}
+
+type Query {
+	# the player profile, the platform is one of psn, xbl, battle, uno or acti.
+	player(platform: String!, username: String!): Player
+	# the full lobby of a match.
+	match(id: ID!): Match
+	# squad of players in the format "platform:username".
+	squad(players: [String!]!): Squad
+}
+
+type Player {
+	username: String!
+	platform: String!
+	level: Float!
+	prestige: Float!
+	totalXp: Float!
+	levelXpRemainder: Float!
+	lifetime: LifetimeStats!
+	weekly: WeeklyStats!
+	# the most recent matches, 20 when first is not set.
+	recentMatches(first: Int): [Match!]!
+}
+
+type Match {
+	id: ID!
+	mode: String!
+	map: String!
+	mapName: String!
+	utcStartSeconds: Float!
+	utcEndSeconds: Float!
+	playerCount: Float!
+	# the stats of the player whose recent matches returned this match, null when the match was fetched by ID.
+	stats: MatchStats
+	# all the players of the lobby, the first 'first' players by kills when first is set.
+	players(first: Int): [MatchPlayer!]!
+	# the teams by placement.
+	teams(first: Int): [Team!]!
+	winningTeam: Team
+}
+
+type MatchStats {
+	kills: Float!
+	deaths: Float!
+	kdRatio: Float!
+	assists: Float!
+	headshots: Float!
+	damageDone: Float!
+	damageTaken: Float!
+	score: Float!
+	gulagKills: Float!
+	gulagDeaths: Float!
+	teamPlacement: Float!
+	timePlayed: Float!
+	totalXp: Float!
+}
+
+type MatchPlayer {
+	uno: String!
+	username: String!
+	team: String!
+	stats: MatchStats!
+}
+
+type Team {
+	name: String!
+	placement: Float!
+	kills: Float!
+	deaths: Float!
+	damageDone: Float!
+	survivalTime: Float!
+	members: [MatchPlayer!]!
+}
+
+type Squad {
+	players: [Player!]!
+	# the recent matches that at least two of the squad members played together.
+	matches(first: Int): [Match!]!
+}
+
+type LifetimeStats {
+	wins: Float!
+	kills: Float!
+	deaths: Float!
+	kdRatio: Float!
+	downs: Float!
+	topFive: Float!
+	topTen: Float!
+	topTwentyFive: Float!
+	revives: Float!
+	gamesPlayed: Float!
+	scorePerMinute: Float!
+	timePlayed: Float!
+}
+
+type WeeklyStats {
+	kills: Float!
+	deaths: Float!
+	kdRatio: Float!
+	matchesPlayed: Float!
+	damageDone: Float!
+	damageTaken: Float!
+	gulagKills: Float!
+	gulagDeaths: Float!
+	headshotPercentage: Float!
+	scorePerMinute: Float!
+}
+`
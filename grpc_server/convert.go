@@ -0,0 +1,168 @@
+// This file is responsible for converting the domain objects into the protobuf messages.
+
+package grpc_server
+
+import (
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/api/warzonepb"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+)
+
+// toRequest validate the player message and convert it into LastGamesRequest.
+func toRequest(p *warzonepb.Player) (activision.LastGamesRequest, error) {
+	if p.GetUsername() == "" {
+		return activision.LastGamesRequest{}, activision.NewValidationError("username", "", "username is required")
+	}
+	if p.GetPlatform() == "" {
+		return activision.LastGamesRequest{}, activision.NewValidationError("platform", "", "platform is required")
+	}
+	return activision.LastGamesRequest{Username: p.GetUsername(), Platform: p.GetPlatform()}, nil
+}
+
+func toMatch(m activision.Match) *warzonepb.Match {
+	s := m.PlayerStats
+	return &warzonepb.Match{
+		MatchId:         m.MatchID,
+		Mode:            m.Mode,
+		Map:             m.Map,
+		MapName:         activision.MapName(m.Map),
+		UtcStartSeconds: int64(m.UtcStartSeconds),
+		UtcEndSeconds:   int64(m.UtcEndSeconds),
+		Team:            m.Player.Team,
+		Stats: &warzonepb.PlayerStats{
+			Kills:         s.Kills,
+			Deaths:        s.Deaths,
+			KdRatio:       s.KdRatio,
+			Assists:       s.Assists,
+			Headshots:     s.Headshots,
+			DamageDone:    s.DamageDone,
+			DamageTaken:   s.DamageTaken,
+			Score:         s.Score,
+			GulagKills:    s.GulagKills,
+			GulagDeaths:   s.GulagDeaths,
+			TeamPlacement: s.TeamPlacement,
+			TimePlayed:    s.TimePlayed,
+			TotalXp:       s.TotalXp,
+		},
+	}
+}
+
+func toLifetime(r *activision.LifetimeAndWeeklyResponse) *warzonepb.GetLifetimeResponse {
+	lifetime := r.Data.Lifetime.Mode.BattleRoyal.Properties
+	weekly := r.Data.Weekly.Mode.BattleRoyalAll.Properties
+	return &warzonepb.GetLifetimeResponse{
+		Player:           &warzonepb.Player{Username: r.Data.Username, Platform: r.Data.Platform},
+		Level:            r.Data.Level,
+		Prestige:         r.Data.Prestige,
+		TotalXp:          r.Data.TotalXp,
+		LevelXpRemainder: r.Data.LevelXpRemainder,
+		Lifetime: &warzonepb.LifetimeStats{
+			Wins:           lifetime.Wins,
+			Kills:          lifetime.Kills,
+			Deaths:         lifetime.Deaths,
+			KdRatio:        lifetime.KdRatio,
+			Downs:          lifetime.Downs,
+			TopFive:        lifetime.TopFive,
+			TopTen:         lifetime.TopTen,
+			TopTwentyFive:  lifetime.TopTwentyFive,
+			Revives:        lifetime.Revives,
+			GamesPlayed:    lifetime.GamesPlayed,
+			ScorePerMinute: lifetime.ScorePerMinute,
+			TimePlayed:     lifetime.TimePlayed,
+		},
+		Weekly: &warzonepb.WeeklyStats{
+			Kills:              weekly.Kills,
+			Deaths:             weekly.Deaths,
+			KdRatio:            weekly.KdRatio,
+			MatchesPlayed:      weekly.MatchesPlayed,
+			DamageDone:         weekly.DamageDone,
+			DamageTaken:        weekly.DamageTaken,
+			GulagKills:         weekly.GulagKills,
+			GulagDeaths:        weekly.GulagDeaths,
+			HeadshotPercentage: weekly.HeadshotPercentage,
+			ScorePerMinute:     weekly.ScorePerMinute,
+		},
+	}
+}
+
+func toGame(matchID string, r *activision.SpecificGameStatsResponse) *warzonepb.GetMatchResponse {
+	response := &warzonepb.GetMatchResponse{MatchId: matchID}
+	if len(r.Data.AllPlayers) > 0 {
+		first := r.Data.AllPlayers[0]
+		response.Mode = first.Mode
+		response.Map = first.Map
+		response.MapName = activision.MapName(first.Map)
+		response.UtcStartSeconds = int64(first.UtcStartSeconds)
+	}
+	for _, team := range r.Teams() {
+		t := &warzonepb.Team{
+			Team:         team.Team,
+			Placement:    team.Placement,
+			Kills:        team.Kills,
+			Deaths:       team.Deaths,
+			DamageDone:   team.DamageDone,
+			SurvivalTime: team.SurvivalTime,
+		}
+		for _, p := range team.Members {
+			s := p.PlayerStats
+			t.Members = append(t.Members, &warzonepb.MatchPlayer{
+				Uno:      p.Player.Uno,
+				Username: p.Player.Username,
+				Stats: &warzonepb.PlayerStats{
+					Kills:         s.Kills,
+					Deaths:        s.Deaths,
+					KdRatio:       s.KdRatio,
+					Assists:       s.Assists,
+					Headshots:     s.Headshots,
+					DamageDone:    s.DamageDone,
+					DamageTaken:   s.DamageTaken,
+					Score:         s.Score,
+					GulagKills:    s.GulagKills,
+					GulagDeaths:   s.GulagDeaths,
+					TeamPlacement: s.TeamPlacement,
+					TimePlayed:    s.TimePlayed,
+					TotalXp:       s.TotalXp,
+				},
+			})
+		}
+		response.Teams = append(response.Teams, t)
+	}
+	if winner, ok := r.WinningTeam(); ok {
+		response.WinningTeam = winner.Team
+	}
+	return response
+}
+
+func toSession(r analytics.SessionReport) *warzonepb.Session {
+	session := &warzonepb.Session{
+		StartSeconds:     r.Start.Unix(),
+		EndSeconds:       r.End.Unix(),
+		TotalTimeSeconds: int64(r.TotalTime.Seconds()),
+		Games:            int32(r.Games),
+		Wins:             int32(r.Wins),
+		Kills:            r.Kills,
+		Deaths:           r.Deaths,
+		DamageDone:       r.DamageDone,
+		AveragePlacement: r.AveragePlacement,
+		BestMatchId:      r.BestGame.MatchID,
+	}
+	for _, p := range r.Players {
+		session.Players = append(session.Players, &warzonepb.SessionPlayer{
+			Username:   p.Username,
+			Games:      int32(p.Games),
+			Kills:      p.Kills,
+			Deaths:     p.Deaths,
+			DamageDone: p.DamageDone,
+		})
+	}
+	return session
+}
+
+func toMatchEvent(event watcher.MatchEvent) *warzonepb.MatchEvent {
+	result := &warzonepb.MatchEvent{Match: toMatch(event.Match), DetectedAtSeconds: event.DetectedAt.Unix()}
+	if len(event.Players) > 0 {
+		result.Player = &warzonepb.Player{Username: event.Players[0].Username, Platform: event.Players[0].Platform}
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package grpc_server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus convert our errors into grpc status, the code is chosen by the http status code of the error the same way
+// that the http controllers return it (see controllers/responses.go).
+func toStatus(err error) error {
+	message := strings.TrimSpace(err.Error())
+	var validationErr *activision.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	var driftErr *activision.SchemaDriftError
+	if errors.As(err, &driftErr) {
+		return status.Error(codes.Internal, message)
+	}
+	var apiErr *activision.ActivisionErrorResponse
+	if errors.As(err, &apiErr) {
+		return status.Error(httpCode(apiErr.StatusCode), message)
+	}
+	return status.Error(codes.Internal, message)
+}
+
+// httpCode return the grpc code that match the http status code.
+func httpCode(statusCode int) codes.Code {
+	switch {
+	case statusCode == http.StatusBadRequest:
+		return codes.InvalidArgument
+	case statusCode == http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case statusCode == http.StatusForbidden:
+		return codes.PermissionDenied
+	case statusCode == http.StatusNotFound:
+		return codes.NotFound
+	case statusCode == http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case statusCode == http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case statusCode >= 500:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
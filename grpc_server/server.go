@@ -0,0 +1,171 @@
+// Package grpc_server implements the WarzoneSquad gRPC service (see api/warzonepb/warzone.proto), the handlers use the same
+// providers and services as the http controllers and only convert the results into the protobuf messages.
+
+package grpc_server
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/api/warzonepb"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+	"github.com/NivNagli/WarzoneSquad_Go/services"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+	"google.golang.org/grpc"
+)
+
+// MinWatchInterval is the minimum time between two polls of a WatchPlayer stream, smaller intervals are raised to it
+// so a single client can't use all the requests of our activision rate limit.
+const MinWatchInterval = time.Minute
+
+// The functions that the handlers use to fetch the data, they are the providers and the services unless changed.
+var (
+	getRecentMatches = services.GetRecentMatches
+	getLifetime      = activision_providers.GetLifetimeAndWeeklyStats
+	getMatch         = activision_providers.GetGameStatsByID
+	getSquadSessions = services.GetSquadSessions
+	getLastGames     = activision_providers.GetLastGamesStats
+	// minWatchInterval is MinWatchInterval unless changed.
+	minWatchInterval = MinWatchInterval
+)
+
+// Server implements warzonepb.WarzoneSquadServer.
+type Server struct {
+	warzonepb.UnimplementedWarzoneSquadServer
+}
+
+// NewServer create grpc server with the WarzoneSquad service registered.
+func NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(opts...)
+	warzonepb.RegisterWarzoneSquadServer(s, &Server{})
+	return s
+}
+
+// Start listen on the address and serve the WarzoneSquad service until the context is canceled, then the server is stopped
+// gracefully so the running requests can finish.
+func Start(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s := NewServer()
+	go func() {
+		<-ctx.Done()
+		s.GracefulStop()
+	}()
+	return s.Serve(listener)
+}
+
+func (s *Server) GetRecentMatches(ctx context.Context, req *warzonepb.GetRecentMatchesRequest) (*warzonepb.GetRecentMatchesResponse, error) {
+	player, err := toRequest(req.GetPlayer())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	result, err := getRecentMatches(player, int(req.GetGames()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &warzonepb.GetRecentMatchesResponse{Player: &warzonepb.Player{Username: result.Username, Platform: result.Platform}}
+	for _, m := range result.Data.Matches {
+		response.Matches = append(response.Matches, toMatch(m))
+	}
+	return response, nil
+}
+
+func (s *Server) GetLifetime(ctx context.Context, req *warzonepb.GetLifetimeRequest) (*warzonepb.GetLifetimeResponse, error) {
+	player, err := toRequest(req.GetPlayer())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	result, err := getLifetime(activision.LifetimeAndWeeklyRequest{Username: player.Username, Platform: player.Platform})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toLifetime(result), nil
+}
+
+func (s *Server) GetMatch(ctx context.Context, req *warzonepb.GetMatchRequest) (*warzonepb.GetMatchResponse, error) {
+	if req.GetMatchId() == "" {
+		return nil, toStatus(activision.NewValidationError("match_id", "", "match_id is required"))
+	}
+	result, err := getMatch(activision.SpecificGameStatsRequest{GameID: req.GetMatchId()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toGame(req.GetMatchId(), result), nil
+}
+
+func (s *Server) GetSquadReport(ctx context.Context, req *warzonepb.GetSquadReportRequest) (*warzonepb.GetSquadReportResponse, error) {
+	players := make([]activision.LastGamesRequest, 0, len(req.GetPlayers()))
+	for _, p := range req.GetPlayers() {
+		player, err := toRequest(p)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		players = append(players, player)
+	}
+	reports, err := getSquadSessions(players, time.Duration(req.GetSessionGapSeconds())*time.Second)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	response := &warzonepb.GetSquadReportResponse{}
+	for _, r := range reports {
+		response.Sessions = append(response.Sessions, toSession(r))
+	}
+	return response, nil
+}
+
+// WatchPlayer poll the player last games until the client cancel the stream and send every new match, the matches that the player
+// already played when the stream started are not sent.
+// The first poll is done before the stream start so an invalid player or an activision error end the stream with the error,
+// the errors of the next polls are only logged by the watcher.
+func (s *Server) WatchPlayer(req *warzonepb.WatchPlayerRequest, stream warzonepb.WarzoneSquad_WatchPlayerServer) error {
+	player, err := toRequest(req.GetPlayer())
+	if err != nil {
+		return toStatus(err)
+	}
+	interval := time.Duration(req.GetIntervalSeconds()) * time.Second
+	if interval <= 0 {
+		interval = watcher.DefaultInterval
+	} else if interval < minWatchInterval {
+		interval = minWatchInterval
+	}
+
+	first, err := getLastGames(player)
+	if err != nil {
+		return toStatus(err)
+	}
+	// The watcher poll immediately when it start, it get the response of the first poll instead of fetching it again.
+	fetch := func(r activision.LastGamesRequest) (*activision.LastGamesResponse, error) {
+		if first != nil {
+			response := first
+			first = nil
+			return response, nil
+		}
+		return getLastGames(r)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	sink := watcher.SinkFunc(func(ctx context.Context, event watcher.MatchEvent) error {
+		if sendErr = stream.Send(toMatchEvent(event)); sendErr != nil {
+			cancel()
+		}
+		return sendErr
+	})
+	w := watcher.New(watcher.Config{
+		Squads:   []watcher.Squad{{Name: player.Username, Players: []activision.LastGamesRequest{player}}},
+		Interval: interval,
+		Store:    store.NewMemoryStore(),
+		Sinks:    []watcher.Sink{sink},
+		Fetch:    fetch,
+	})
+	if err := w.Run(ctx); err != nil {
+		return toStatus(err)
+	}
+	return sendErr
+}
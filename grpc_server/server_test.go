@@ -0,0 +1,273 @@
+package grpc_server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/api/warzonepb"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const fixturesDir = "../domain/activision/OfficialResponsesFromActiApi"
+
+var testPlayer = &warzonepb.Player{Username: "inbargab#6797419", Platform: "uno"}
+
+// newTestClient serve the service over in memory connection and return client that is connected to it.
+func newTestClient(t *testing.T) warzonepb.WarzoneSquadClient {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	s := NewServer()
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return warzonepb.NewWarzoneSquadClient(conn)
+}
+
+// stub replace the variable with the value until the end of the test.
+func stub[T any](t *testing.T, variable *T, value T) {
+	t.Helper()
+	old := *variable
+	*variable = value
+	t.Cleanup(func() { *variable = old })
+}
+
+func readFixture(t *testing.T, name string, result interface{}) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(fixturesDir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func lastGamesFixture(t *testing.T) *activision.LastGamesResponse {
+	var result activision.LastGamesResponse
+	readFixture(t, "lastGamesResponse.json", &result)
+	result.Username, result.Platform = testPlayer.Username, testPlayer.Platform
+	return &result
+}
+
+func assertCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Fatalf("expected code %s, got %v", code, err)
+	}
+}
+
+func TestGetRecentMatches(t *testing.T) {
+	fixture := lastGamesFixture(t)
+	stub(t, &getRecentMatches, func(player activision.LastGamesRequest, games int) (*activision.LastGamesResponse, error) {
+		if player.Username != testPlayer.Username || player.Platform != testPlayer.Platform {
+			t.Errorf("unexpected player %+v", player)
+		}
+		result := *fixture
+		result.Data.Matches = result.Data.Matches[:games]
+		return &result, nil
+	})
+	client := newTestClient(t)
+
+	response, err := client.GetRecentMatches(context.Background(), &warzonepb.GetRecentMatchesRequest{Player: testPlayer, Games: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Matches) != 3 {
+		t.Fatalf("expected 3 matches, got %d", len(response.Matches))
+	}
+	first := fixture.Data.Matches[0]
+	if response.Matches[0].MatchId != first.MatchID || response.Matches[0].Stats.Kills != first.PlayerStats.Kills {
+		t.Errorf("the first match was not converted, got %+v", response.Matches[0])
+	}
+	if response.Player.GetUsername() != testPlayer.Username {
+		t.Errorf("expected username %s, got %s", testPlayer.Username, response.Player.GetUsername())
+	}
+
+	_, err = client.GetRecentMatches(context.Background(), &warzonepb.GetRecentMatchesRequest{Player: &warzonepb.Player{Platform: "uno"}})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestGetLifetime(t *testing.T) {
+	var fixture activision.LifetimeAndWeeklyResponse
+	readFixture(t, "lifetimeAndweeklyResponse.json", &fixture)
+	stub(t, &getLifetime, func(r activision.LifetimeAndWeeklyRequest) (*activision.LifetimeAndWeeklyResponse, error) {
+		if r.Username == "missing#1" {
+			return nil, &activision.ActivisionErrorResponse{Message: "Error: not found\n", StatusCode: http.StatusNotFound}
+		}
+		return &fixture, nil
+	})
+	client := newTestClient(t)
+
+	response, err := client.GetLifetime(context.Background(), &warzonepb.GetLifetimeRequest{Player: testPlayer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Level != fixture.Data.Level || response.Lifetime.GetKills() != fixture.Data.Lifetime.Mode.BattleRoyal.Properties.Kills {
+		t.Errorf("the lifetime stats were not converted, got %+v", response)
+	}
+
+	_, err = client.GetLifetime(context.Background(), &warzonepb.GetLifetimeRequest{Player: &warzonepb.Player{Username: "missing#1", Platform: "battle"}})
+	assertCode(t, err, codes.NotFound)
+}
+
+func TestGetMatch(t *testing.T) {
+	var fixture struct {
+		Data activision.SpecificGameStatsResponse `json:"data"`
+	}
+	readFixture(t, "successResponseFromMatchID.json", &fixture)
+	matchID := fixture.Data.Data.AllPlayers[0].MatchID
+	stub(t, &getMatch, func(r activision.SpecificGameStatsRequest) (*activision.SpecificGameStatsResponse, error) {
+		if r.GameID != matchID {
+			return nil, &activision.ActivisionErrorResponse{Message: "Error: bad gateway\n", StatusCode: http.StatusBadGateway}
+		}
+		return &fixture.Data, nil
+	})
+	client := newTestClient(t)
+
+	response, err := client.GetMatch(context.Background(), &warzonepb.GetMatchRequest{MatchId: matchID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	teams := fixture.Data.Teams()
+	if len(response.Teams) != len(teams) {
+		t.Fatalf("expected %d teams, got %d", len(teams), len(response.Teams))
+	}
+	members := 0
+	for _, team := range response.Teams {
+		members += len(team.Members)
+	}
+	if members != len(fixture.Data.Data.AllPlayers) {
+		t.Errorf("expected %d players in the teams, got %d", len(fixture.Data.Data.AllPlayers), members)
+	}
+	if response.WinningTeam == "" {
+		t.Error("expected winning team")
+	}
+
+	_, err = client.GetMatch(context.Background(), &warzonepb.GetMatchRequest{})
+	assertCode(t, err, codes.InvalidArgument)
+	_, err = client.GetMatch(context.Background(), &warzonepb.GetMatchRequest{MatchId: "1"})
+	assertCode(t, err, codes.Unavailable)
+}
+
+func TestGetSquadReport(t *testing.T) {
+	start := time.Unix(1638419782, 0)
+	stub(t, &getSquadSessions, func(players []activision.LastGamesRequest, gap time.Duration) ([]analytics.SessionReport, error) {
+		if len(players) != 2 {
+			t.Errorf("expected 2 players, got %d", len(players))
+		}
+		if gap != 30*time.Minute {
+			t.Errorf("expected gap of 30m, got %s", gap)
+		}
+		return []analytics.SessionReport{{
+			Start:     start,
+			End:       start.Add(2 * time.Hour),
+			TotalTime: 90 * time.Minute,
+			Games:     4,
+			Wins:      1,
+			Kills:     25,
+			BestGame:  analytics.SessionGame{MatchID: "938768169708722377"},
+			Players:   []analytics.SessionPlayerReport{{Username: "a", Games: 4}, {Username: "b", Games: 3}},
+		}}, nil
+	})
+	client := newTestClient(t)
+
+	response, err := client.GetSquadReport(context.Background(), &warzonepb.GetSquadReportRequest{
+		Players:           []*warzonepb.Player{testPlayer, {Username: "nivGolanigo#1234", Platform: "battle"}},
+		SessionGapSeconds: 1800,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(response.Sessions))
+	}
+	session := response.Sessions[0]
+	if session.StartSeconds != start.Unix() || session.TotalTimeSeconds != 5400 || session.Games != 4 || len(session.Players) != 2 {
+		t.Errorf("the session was not converted, got %+v", session)
+	}
+
+	_, err = client.GetSquadReport(context.Background(), &warzonepb.GetSquadReportRequest{Players: []*warzonepb.Player{{Username: "a"}}})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestWatchPlayer(t *testing.T) {
+	fixture := lastGamesFixture(t)
+	newMatch := fixture.Data.Matches[0]
+	newMatch.MatchID = "1"
+	polls := make(chan int, 10)
+	calls := 0
+	stub(t, &minWatchInterval, time.Second)
+	stub(t, &getLastGames, func(r activision.LastGamesRequest) (*activision.LastGamesResponse, error) {
+		calls++
+		polls <- calls
+		result := *fixture
+		if calls > 1 {
+			result.Data.Matches = append([]activision.Match{newMatch}, fixture.Data.Matches...)
+		}
+		return &result, nil
+	})
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := client.WatchPlayer(ctx, &warzonepb.WatchPlayerRequest{Player: testPlayer, IntervalSeconds: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Match.GetMatchId() != newMatch.MatchID {
+		t.Errorf("expected only the new match %s, got %s", newMatch.MatchID, event.Match.GetMatchId())
+	}
+	if event.Player.GetUsername() != testPlayer.Username {
+		t.Errorf("expected player %s, got %s", testPlayer.Username, event.Player.GetUsername())
+	}
+	// The first poll of the watcher use the response of the up front poll, so the new match is found by the second request.
+	if len(polls) != 2 {
+		t.Errorf("expected 2 requests to activision, got %d", len(polls))
+	}
+}
+
+func TestWatchPlayerFirstPollFails(t *testing.T) {
+	stub(t, &getLastGames, func(r activision.LastGamesRequest) (*activision.LastGamesResponse, error) {
+		return nil, &activision.ActivisionErrorResponse{Message: "Error: the player profile is private\n", StatusCode: http.StatusForbidden}
+	})
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.WatchPlayer(ctx, &warzonepb.WatchPlayerRequest{Player: testPlayer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	assertCode(t, err, codes.PermissionDenied)
+
+	stream, err = client.WatchPlayer(ctx, &warzonepb.WatchPlayerRequest{Player: &warzonepb.Player{Username: "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	assertCode(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,333 @@
+// Package livefeed push the new matches of the watch mode to browser clients over WebSocket, the Hub implements the
+// watcher.Sink interface and every connection subscribe to the squads and the players that it wants to receive.
+
+package livefeed
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/services"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// writeWait is the time allowed to write a message to the client.
+	writeWait = 10 * time.Second
+	// pongWait is the time allowed to read the next pong (or any other message) from the client.
+	pongWait = 60 * time.Second
+	// pingPeriod is the time between the pings, it must be less than pongWait.
+	pingPeriod = pongWait * 9 / 10
+	// maxMessageSize is the maximum size of a client message.
+	maxMessageSize = 1024
+	// sendBuffer is the number of messages that wait for a slow client, when it is full the client is disconnected.
+	sendBuffer = 32
+	// maxSubscriptions is the maximum number of squads and players that a single connection can subscribe to.
+	maxSubscriptions = 50
+)
+
+var (
+	errMissingTarget        = activision.NewValidationError("subscription", "", "squad or player is required")
+	errTooManySubscriptions = activision.NewValidationError("subscription", "", "too many subscriptions, the limit is "+strconv.Itoa(maxSubscriptions))
+)
+
+// Options are the options of the hub.
+// When AllowedOrigins is empty only same origin connections are accepted, "*" accept any origin.
+type Options struct {
+	AllowedOrigins []string
+}
+
+// Hub hold the connected clients and send them the match events of their subscriptions.
+type Hub struct {
+	upgrader websocket.Upgrader
+	mutex    sync.Mutex
+	clients  map[*client]struct{}
+	closed   bool
+}
+
+// NewHub create hub without clients.
+func NewHub(options Options) *Hub {
+	h := &Hub{clients: make(map[*client]struct{})}
+	if len(options.AllowedOrigins) > 0 {
+		h.upgrader.CheckOrigin = func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			for _, allowed := range options.AllowedOrigins {
+				if allowed == "*" || strings.EqualFold(allowed, origin) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return h
+}
+
+// Notify send the event to every client that subscribed to the squad or to one of the players, it never blocks,
+// a client that does not read its messages is disconnected.
+func (h *Hub) Notify(ctx context.Context, event watcher.MatchEvent) error {
+	e := NewMatchEvent(event)
+	message, err := json.Marshal(ServerMessage{Type: TypeMatch, Match: &e})
+	if err != nil {
+		return err
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for c := range h.clients {
+		if !c.subscribed(e) {
+			continue
+		}
+		select {
+		case c.send <- message:
+		default:
+			slog.WarnContext(ctx, "live feed client is too slow, disconnecting it", slog.String("remote_addr", c.remoteAddr))
+			h.remove(c)
+		}
+	}
+	return nil
+}
+
+// ServeHTTP upgrade the request to WebSocket and serve the client until it disconnects, the initial subscriptions
+// can be passed in the query: /live?squad=the+boys&player=uno:nivGolanigo%231234
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already wrote the error response.
+		return
+	}
+	c := &client{
+		hub:        h,
+		conn:       conn,
+		remoteAddr: r.RemoteAddr,
+		send:       make(chan []byte, sendBuffer),
+		squads:     make(map[string]bool),
+		players:    make(map[string]bool),
+	}
+	h.mutex.Lock()
+	if h.closed {
+		h.mutex.Unlock()
+		conn.Close()
+		return
+	}
+	h.clients[c] = struct{}{}
+	h.mutex.Unlock()
+	slog.Debug("live feed client connected", slog.String("remote_addr", c.remoteAddr))
+
+	go c.writePump()
+	query := r.URL.Query()
+	for _, squad := range query["squad"] {
+		c.handle(ClientMessage{Type: TypeSubscribe, Squad: squad})
+	}
+	for _, player := range query["player"] {
+		c.handle(ClientMessage{Type: TypeSubscribe, Player: player})
+	}
+	c.readPump()
+}
+
+// Close disconnect all the clients, new connections are rejected after Close.
+func (h *Hub) Close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.closed = true
+	for c := range h.clients {
+		h.remove(c)
+	}
+}
+
+// Clients return the number of the connected clients.
+func (h *Hub) Clients() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.clients)
+}
+
+// remove unregister the client and close its send channel, the write pump then close the connection.
+// The caller must hold the hub lock.
+func (h *Hub) remove(c *client) {
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+	delete(h.clients, c)
+	close(c.send)
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.remove(c)
+}
+
+/*************************************************** Client ***************************************************/
+
+// client is a single WebSocket connection, the read pump handle the client messages and the write pump is the only
+// goroutine that write to the connection.
+type client struct {
+	hub        *Hub
+	conn       *websocket.Conn
+	remoteAddr string
+	send       chan []byte
+
+	mutex   sync.Mutex
+	squads  map[string]bool
+	players map[string]bool // lower case platform:username
+}
+
+func (c *client) subscribed(e MatchEvent) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.squads[e.Squad] {
+		return true
+	}
+	for _, p := range e.Players {
+		if c.players[strings.ToLower(p)] {
+			return true
+		}
+	}
+	return false
+}
+
+// readPump read the client messages until the connection is closed or the client stopped to answer the pings.
+func (c *client) readPump() {
+	defer func() {
+		c.hub.unregister(c)
+		c.conn.Close()
+		slog.Debug("live feed client disconnected", slog.String("remote_addr", c.remoteAddr))
+	}()
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.Debug("live feed read failed", slog.String("remote_addr", c.remoteAddr), slog.String("error", err.Error()))
+			}
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		var message ClientMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			c.reply(ServerMessage{Type: TypeError, Message: "the message must be json object"})
+			continue
+		}
+		c.handle(message)
+	}
+}
+
+// handle apply the client message and send the reply.
+func (c *client) handle(message ClientMessage) {
+	switch message.Type {
+	case TypePing:
+		c.reply(ServerMessage{Type: TypePong})
+	case TypeSubscribe, TypeUnsubscribe:
+		if err := c.subscribe(message); err != nil {
+			c.reply(ServerMessage{Type: TypeError, Message: strings.TrimSpace(err.Error())})
+			return
+		}
+		c.reply(c.subscriptions())
+	default:
+		c.reply(ServerMessage{Type: TypeError, Message: "unknown message type '" + message.Type + "'"})
+	}
+}
+
+func (c *client) subscribe(message ClientMessage) error {
+	if message.Squad == "" && message.Player == "" {
+		return errMissingTarget
+	}
+	var player string
+	if message.Player != "" {
+		r, err := services.ParsePlayer(message.Player)
+		if err != nil {
+			return err
+		}
+		player = strings.ToLower(store.PlayerKey(r.Platform, r.Username))
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if message.Type == TypeUnsubscribe {
+		delete(c.squads, message.Squad)
+		delete(c.players, player)
+		return nil
+	}
+	if len(c.squads)+len(c.players) >= maxSubscriptions {
+		return errTooManySubscriptions
+	}
+	if message.Squad != "" {
+		c.squads[message.Squad] = true
+	}
+	if player != "" {
+		c.players[player] = true
+	}
+	return nil
+}
+
+func (c *client) subscriptions() ServerMessage {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	message := ServerMessage{Type: TypeSubscribed, Squads: []string{}, Players: []string{}}
+	for squad := range c.squads {
+		message.Squads = append(message.Squads, squad)
+	}
+	for player := range c.players {
+		message.Players = append(message.Players, player)
+	}
+	sort.Strings(message.Squads)
+	sort.Strings(message.Players)
+	return message
+}
+
+// reply queue the message for the write pump, the reply is dropped when the client was already removed.
+func (c *client) reply(message ServerMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	c.hub.mutex.Lock()
+	defer c.hub.mutex.Unlock()
+	if _, ok := c.hub.clients[c]; !ok {
+		return
+	}
+	select {
+	case c.send <- data:
+	default:
+		c.hub.remove(c)
+	}
+}
+
+// writePump send the queued messages and the pings, it close the connection when the send channel is closed.
+func (c *client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package livefeed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+	"github.com/gorilla/websocket"
+)
+
+// newTestServer serve the hub over httptest server and close both of them at the end of the test.
+func newTestServer(t *testing.T, options Options) (*Hub, *httptest.Server) {
+	t.Helper()
+	hub := NewHub(options)
+	server := httptest.NewServer(hub)
+	t.Cleanup(func() {
+		hub.Close()
+		server.Close()
+	})
+	return hub, server
+}
+
+// dial connect to the hub with the query and the headers, the connection is closed at the end of the test.
+func dial(t *testing.T, server *httptest.Server, query string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/live"
+	if query != "" {
+		url += "?" + query
+	}
+	conn, response, err := websocket.DefaultDialer.Dial(url, header)
+	if err == nil {
+		t.Cleanup(func() { conn.Close() })
+	}
+	return conn, response, err
+}
+
+func mustDial(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := dial(t, server, query, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) ServerMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var message ServerMessage
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+func send(t *testing.T, conn *websocket.Conn, message ClientMessage) ServerMessage {
+	t.Helper()
+	if err := conn.WriteJSON(message); err != nil {
+		t.Fatal(err)
+	}
+	return readMessage(t, conn)
+}
+
+// expectNoMatch send ping and expect that the pong is the next message, the replies are queued after the match
+// messages so a match that was routed to the client would be read first.
+func expectNoMatch(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+	if message := send(t, conn, ClientMessage{Type: TypePing}); message.Type != TypePong {
+		t.Fatalf("expected pong, got %+v", message)
+	}
+}
+
+func matchEvent(squad string, players ...string) watcher.MatchEvent {
+	event := watcher.MatchEvent{Squad: squad, Match: activision.Match{MatchID: "1"}}
+	for _, p := range players {
+		platform, username, _ := strings.Cut(p, ":")
+		event.Players = append(event.Players, watcher.PlayerMatch{Username: username, Platform: platform})
+	}
+	return event
+}
+
+func TestHubSubscriptions(t *testing.T) {
+	_, server := newTestServer(t, Options{})
+	conn := mustDial(t, server, "squad=the+boys")
+	if message := readMessage(t, conn); message.Type != TypeSubscribed || strings.Join(message.Squads, ",") != "the boys" {
+		t.Fatalf("expected the query subscription, got %+v", message)
+	}
+
+	tests := []struct {
+		name    string
+		message ClientMessage
+		squads  string
+		players string
+		err     string
+	}{
+		{name: "subscribe player", message: ClientMessage{Type: TypeSubscribe, Player: "uno:Niv#1234"}, squads: "the boys", players: "uno:niv#1234"},
+		{name: "subscribe squad", message: ClientMessage{Type: TypeSubscribe, Squad: "alpha"}, squads: "alpha,the boys", players: "uno:niv#1234"},
+		{name: "unsubscribe squad", message: ClientMessage{Type: TypeUnsubscribe, Squad: "the boys"}, squads: "alpha", players: "uno:niv#1234"},
+		{name: "unsubscribe player", message: ClientMessage{Type: TypeUnsubscribe, Player: "uno:NIV#1234"}, squads: "alpha"},
+		{name: "missing target", message: ClientMessage{Type: TypeSubscribe}, err: "squad or player is required"},
+		{name: "invalid player", message: ClientMessage{Type: TypeSubscribe, Player: "niv"}, err: "platform:username"},
+		{name: "unknown type", message: ClientMessage{Type: "join"}, err: "unknown message type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := send(t, conn, tt.message)
+			if tt.err != "" {
+				if message.Type != TypeError || !strings.Contains(message.Message, tt.err) {
+					t.Fatalf("expected error %q, got %+v", tt.err, message)
+				}
+				return
+			}
+			if message.Type != TypeSubscribed {
+				t.Fatalf("expected subscribed message, got %+v", message)
+			}
+			if got := strings.Join(message.Squads, ","); got != tt.squads {
+				t.Errorf("expected squads %q, got %q", tt.squads, got)
+			}
+			if got := strings.Join(message.Players, ","); got != tt.players {
+				t.Errorf("expected players %q, got %q", tt.players, got)
+			}
+		})
+	}
+}
+
+func TestHubNotifyRouting(t *testing.T) {
+	hub, server := newTestServer(t, Options{})
+	squadClient := mustDial(t, server, "squad=alpha")
+	playerClient := mustDial(t, server, "player=psn:Bob")
+	readMessage(t, squadClient)
+	readMessage(t, playerClient)
+
+	tests := []struct {
+		name         string
+		event        watcher.MatchEvent
+		squadClient  bool
+		playerClient bool
+	}{
+		{name: "squad subscription", event: matchEvent("alpha", "psn:alice"), squadClient: true},
+		{name: "player subscription ignore case", event: matchEvent("beta", "psn:alice", "psn:bob"), playerClient: true},
+		{name: "both subscriptions", event: matchEvent("alpha", "psn:bob"), squadClient: true, playerClient: true},
+		{name: "no subscription", event: matchEvent("beta", "xbl:bob")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := hub.Notify(context.Background(), tt.event); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range []struct {
+				conn     *websocket.Conn
+				expected bool
+			}{{squadClient, tt.squadClient}, {playerClient, tt.playerClient}} {
+				if !c.expected {
+					expectNoMatch(t, c.conn)
+					continue
+				}
+				message := readMessage(t, c.conn)
+				if message.Type != TypeMatch || message.Match == nil || message.Match.Squad != tt.event.Squad {
+					t.Fatalf("expected match of squad %q, got %+v", tt.event.Squad, message)
+				}
+			}
+		})
+	}
+}
+
+func TestHubSubscriptionLimit(t *testing.T) {
+	_, server := newTestServer(t, Options{})
+	conn := mustDial(t, server, "")
+	for i := 0; i < maxSubscriptions; i++ {
+		if message := send(t, conn, ClientMessage{Type: TypeSubscribe, Squad: "squad" + strconv.Itoa(i)}); message.Type != TypeSubscribed {
+			t.Fatalf("subscription %d: expected subscribed message, got %+v", i, message)
+		}
+	}
+	message := send(t, conn, ClientMessage{Type: TypeSubscribe, Player: "uno:niv#1234"})
+	if message.Type != TypeError || !strings.Contains(message.Message, "too many subscriptions") {
+		t.Fatalf("expected subscription limit error, got %+v", message)
+	}
+	// Unsubscribe is always allowed and it free a place for a new subscription.
+	if message := send(t, conn, ClientMessage{Type: TypeUnsubscribe, Squad: "squad0"}); message.Type != TypeSubscribed || len(message.Squads) != maxSubscriptions-1 {
+		t.Fatalf("expected %d squads after unsubscribe, got %+v", maxSubscriptions-1, message)
+	}
+	if message := send(t, conn, ClientMessage{Type: TypeSubscribe, Player: "uno:niv#1234"}); message.Type != TypeSubscribed {
+		t.Fatalf("expected subscribed message after unsubscribe, got %+v", message)
+	}
+}
+
+// A client that does not read its messages fill the socket buffers and then the send buffer, Notify must not block on
+// it and disconnect it instead.
+func TestHubDisconnectSlowClient(t *testing.T) {
+	hub, server := newTestServer(t, Options{})
+	slow := mustDial(t, server, "squad=alpha")
+	fast := mustDial(t, server, "squad=beta")
+	readMessage(t, slow)
+	readMessage(t, fast)
+
+	event := matchEvent("alpha", "psn:alice")
+	done := make(chan int)
+	go func() {
+		for i := 1; i <= 1000000; i++ {
+			hub.Notify(context.Background(), event)
+			if hub.Clients() == 1 {
+				done <- i
+				return
+			}
+		}
+		done <- 0
+	}()
+	select {
+	case sent := <-done:
+		if sent == 0 {
+			t.Fatal("the slow client was not disconnected")
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("Notify blocked on the slow client")
+	}
+	expectNoMatch(t, fast)
+}
+
+func TestHubOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		ok      bool
+	}{
+		{name: "same origin by default", origin: "same", ok: true},
+		{name: "other origin by default", origin: "https://evil.example"},
+		{name: "allowed origin", allowed: []string{"https://app.example"}, origin: "https://APP.example", ok: true},
+		{name: "not allowed origin", allowed: []string{"https://app.example"}, origin: "https://evil.example"},
+		{name: "any origin", allowed: []string{"*"}, origin: "https://evil.example", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub, server := newTestServer(t, Options{AllowedOrigins: tt.allowed})
+			origin := tt.origin
+			if origin == "same" {
+				origin = server.URL
+			}
+			_, response, err := dial(t, server, "", http.Header{"Origin": {origin}})
+			if tt.ok {
+				if err != nil {
+					t.Fatalf("expected the connection to be accepted, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected the connection to be rejected")
+			}
+			if response == nil || response.StatusCode != http.StatusForbidden {
+				t.Fatalf("expected status %d, got %v", http.StatusForbidden, response)
+			}
+			if hub.Clients() != 0 {
+				t.Errorf("expected no clients, got %d", hub.Clients())
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// This file contains the messages of the live feed protocol, all the messages are json objects with a "type" field.
+//
+// Client to server:
+//
+//	{"type": "subscribe", "squad": "the boys"}
+//	{"type": "subscribe", "player": "uno:nivGolanigo#1234"}
+//	{"type": "unsubscribe", "squad": "the boys"}
+//	{"type": "ping"}
+//
+// Server to client:
+//
+//	{"type": "subscribed", "squads": [...], "players": [...]}   the subscriptions after every change
+//	{"type": "match", "match": {...}}                            new match of a subscribed squad or player
+//	{"type": "pong"}
+//	{"type": "error", "message": "..."}
+
+package livefeed
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/notifier"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+)
+
+// The types of the messages.
+const (
+	TypeSubscribe   = "subscribe"
+	TypeUnsubscribe = "unsubscribe"
+	TypePing        = "ping"
+	TypeSubscribed  = "subscribed"
+	TypeMatch       = "match"
+	TypePong        = "pong"
+	TypeError       = "error"
+)
+
+// The thresholds of the player achievements.
+const (
+	highKillsThreshold  = 10
+	highDamageThreshold = 3000
+)
+
+// ClientMessage is a message that the client send.
+type ClientMessage struct {
+	Type   string `json:"type"`
+	Squad  string `json:"squad,omitempty"`
+	Player string `json:"player,omitempty"` // platform:username
+}
+
+// ServerMessage is a message that the server send, only the fields of the message type are set.
+type ServerMessage struct {
+	Type    string      `json:"type"`
+	Squads  []string    `json:"squads,omitempty"`
+	Players []string    `json:"players,omitempty"`
+	Match   *MatchEvent `json:"match,omitempty"`
+	Message string      `json:"message,omitempty"`
+}
+
+// Achievement is a notable thing that happened in the match, Player is empty for the achievements of the whole squad.
+type Achievement struct {
+	Player string `json:"player,omitempty"`
+	Name   string `json:"name"`
+}
+
+// MatchEvent is the new match of the watcher as it is sent to the clients.
+type MatchEvent struct {
+	Squad        string           `json:"squad"`
+	Players      []string         `json:"players"` // platform:username of the squad members that played the match
+	Summary      notifier.Summary `json:"summary"`
+	Achievements []Achievement    `json:"achievements"`
+	DetectedAt   time.Time        `json:"detectedAt"`
+}
+
+// NewMatchEvent build the live feed event from the event of the watcher.
+func NewMatchEvent(event watcher.MatchEvent) MatchEvent {
+	e := MatchEvent{Squad: event.Squad, Summary: notifier.SummaryFromEvent(event), DetectedAt: event.DetectedAt}
+	for _, p := range event.Players {
+		e.Players = append(e.Players, store.PlayerKey(p.Platform, p.Username))
+	}
+	e.Achievements = achievements(e.Summary)
+	return e
+}
+
+// achievements return the notable achievements of the match, the wins and the top 5 finishes of the squad, the high kills
+// and high damage games and the awards of every player.
+func achievements(s notifier.Summary) []Achievement {
+	result := []Achievement{}
+	if s.Won {
+		result = append(result, Achievement{Name: "Victory"})
+	} else if s.Placement > 0 && s.Placement <= 5 {
+		result = append(result, Achievement{Name: "Top 5"})
+	}
+	for _, p := range s.Players {
+		if p.Kills >= highKillsThreshold {
+			result = append(result, Achievement{Player: p.Username, Name: "Double digit kills"})
+		}
+		if p.Damage >= highDamageThreshold {
+			result = append(result, Achievement{Player: p.Username, Name: fmt.Sprintf("%d+ damage", highDamageThreshold)})
+		}
+		for _, award := range p.Awards {
+			result = append(result, Achievement{Player: p.Username, Name: award})
+		}
+	}
+	return result
+}
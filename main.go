@@ -3,33 +3,55 @@ package main
 
 import (
 	"fmt"
-
-	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
-	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+	"os"
+	"sort"
 )
 
-func main() {
-	// lastGamesReq := activision.LastGamesRequest{Username: "inbargab#6797419", Platform: "uno"}
-	// result, err := activision_providers.GetLastGamesStatsByCycles(lastGamesReq, 5)
-	// if err != nil {
-	// 	fmt.Println(err)
-	// } else {
-	// 	fmt.Println(result.Data.Matches)
-	// }
+// command is a single sub command of the CLI, the run function receive the arguments that come after the command name.
+type command struct {
+	description string
+	run         func(args []string) error
+}
 
-	// lifetimeAndWeekly := activision.LifetimeAndWeeklyRequest{Username: "inbargab#6797419", Platform: "uno"}
-	// result, err := activision_providers.GetLifetimeAndWeeklyStats(lifetimeAndWeekly)
-	// if err != nil {
-	// 	fmt.Println(err)
-	// } else {
-	// 	fmt.Println(result)
-	// }
+var commands = map[string]command{
+	"awards":      {"the awards of the players over their games: awards [-games N] [-store dir] [-json] platform:username...", runAwards},
+	"maps":        {"performance by map of the tracked players: maps [-store dir] [-json] [platform:username...]", runMaps},
+	"match":       {"print the teams and the players of a specific game: match [-json] [-log-level L] <gameID>", runMatch},
+	"compare":     {"compare two or more players: compare [-games N] [-json] platform:username platform:username...", runCompare},
+	"objectives":  {"who loots, buys, revives and runs contracts in the squad: objectives [-games N] [-json] platform:username", runObjectives},
+	"progress":    {"xp rate and projected time to the next level: progress [-games N] [-json] platform:username", runProgress},
+	"report":      {"html report with charts: report [-games N] [-store dir] [-out report.html] platform:username...", runReport},
+	"serve":       {"start the http server: serve [-addr :8080] [-store dir] [-cache-ttl 30s]", runServe},
+	"grpc":        {"start the grpc server: grpc [-addr :9090] [-cache-ttl 30s]", runGrpc},
+	"gulag":       {"gulag analytics of the tracked players: gulag [-store dir] [-json] [platform:username...]", runGulag},
+	"leaderboard": {"rank the tracked players: leaderboard [-window week] [-metric kd] [-store dir] [-json]", runLeaderboard},
+	"watch":       {"poll tracked squads for new matches: watch [-config squads.json] [-player platform:username...] [-interval 5m] [-webhooks webhooks.json] [-feed-addr :8081] [-metrics-addr :9100]", runWatch},
+}
 
-	gameStatsByID := activision.SpecificGameStatsRequest{"938768169708722377"}
-	result, err := activision_providers.GetGameStatsByID(gameStatsByID)
-	if err != nil {
-		fmt.Println(err)
-	} else {
-		fmt.Println(result.Data)
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprint(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: WarzoneSquad_Go <command> [arguments]")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].description)
 	}
 }
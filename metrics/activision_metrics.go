@@ -0,0 +1,108 @@
+// This file contains the metrics of the requests that we send to the official API, they are collected by registering
+// the ActivisionRecorder with the MetricsMiddleware of the providers pipeline.
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/config"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+)
+
+// The outcome label values of the requests counter.
+const (
+	OutcomeSuccess       = "success"
+	OutcomeCacheHit      = "cache_hit"
+	OutcomeInvalidInput  = "invalid_input"
+	OutcomeUpstreamError = "upstream_error"
+	OutcomeSchemaDrift   = "schema_drift"
+)
+
+var (
+	activisionRequests = NewCounter("warzone_activision_requests_total",
+		"Requests to the activision API by endpoint and outcome.", "endpoint", "outcome")
+	activisionRequestDuration = NewHistogram("warzone_activision_request_duration_seconds",
+		"Latency of the requests to the activision API including retries.", nil, "endpoint")
+	activisionRetries = NewCounter("warzone_activision_retries_total",
+		"Requests to the activision API that was sent again after temporary failure.", "endpoint")
+	activisionCacheHits = NewCounter("warzone_activision_cache_hits_total",
+		"Requests that was answered from the cache without calling the activision API.", "endpoint")
+	activisionUpstreamStatus = NewCounter("warzone_activision_upstream_responses_total",
+		"Responses received from the activision API by http status code.", "endpoint", "status")
+	_ = NewGaugeFunc("warzone_activision_token_pool_capacity",
+		"Activision token sets that the application can use.", tokenPoolCapacity)
+	_ = NewGaugeFunc("warzone_activision_token_pool_available",
+		"Activision token sets that are set in the environment variables and was not rejected by the activision API.", tokenPoolAvailable)
+)
+
+// tokensRejected is true from the time that the activision API answered 401 or 403 until the next successful response.
+var tokensRejected atomic.Bool
+
+// ActivisionRecorder implements the MetricsRecorder interface of the providers pipeline.
+type ActivisionRecorder struct{}
+
+// ObserveCall update the metrics with the result of the call.
+func (ActivisionRecorder) ObserveCall(c *activision_providers.Call, err error, duration time.Duration) {
+	activisionRequests.Inc(c.Endpoint, callOutcome(c, err))
+	if c.CacheHit {
+		activisionCacheHits.Inc(c.Endpoint)
+		return
+	}
+	activisionRequestDuration.Observe(duration.Seconds(), c.Endpoint)
+	if c.Attempts > 1 {
+		activisionRetries.Add(float64(c.Attempts-1), c.Endpoint)
+	}
+	if c.StatusCode != 0 {
+		activisionUpstreamStatus.Inc(c.Endpoint, strconv.Itoa(c.StatusCode))
+	}
+	switch {
+	case c.StatusCode == http.StatusUnauthorized || c.StatusCode == http.StatusForbidden:
+		tokensRejected.Store(true)
+	case err == nil:
+		tokensRejected.Store(false)
+	}
+}
+
+// RegisterActivisionMetrics add the metrics middleware with the ActivisionRecorder to the providers pipeline.
+func RegisterActivisionMetrics() {
+	activision_providers.Use(activision_providers.MetricsMiddleware(ActivisionRecorder{}))
+}
+
+func callOutcome(c *activision_providers.Call, err error) string {
+	if err == nil {
+		if c.CacheHit {
+			return OutcomeCacheHit
+		}
+		return OutcomeSuccess
+	}
+	// The request arguments are validated before the pipeline, so here invalid input is only what the official API rejected (unknown game ID).
+	var apiErr *activision.ActivisionErrorResponse
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 400 {
+		return OutcomeInvalidInput
+	}
+	var driftErr *activision.SchemaDriftError
+	if errors.As(err, &driftErr) {
+		return OutcomeSchemaDrift
+	}
+	return OutcomeUpstreamError
+}
+
+// tokenPoolCapacity return the number of the token sets, we have only one set of tokens that is read from the environment variables.
+func tokenPoolCapacity() float64 {
+	return 1
+}
+
+// tokenPoolAvailable return the number of the token sets that can be used, a set is not available when one of its environment
+// variables is missing or when the activision API rejected it.
+func tokenPoolAvailable() float64 {
+	if _, err := config.GetActivisionAccessTokens(); err != nil || tokensRejected.Load() {
+		return 0
+	}
+	return 1
+}
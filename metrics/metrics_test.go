@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+)
+
+// scrape return the text of the metrics handler.
+func scrape(t *testing.T) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Fatalf("unexpected content type %q", contentType)
+	}
+	return recorder.Body.String()
+}
+
+func assertLines(t *testing.T, text string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(text, "\n"+line+"\n") {
+			t.Errorf("expected the line %q in the metrics:\n%s", line, text)
+		}
+	}
+}
+
+func TestHandlerScrape(t *testing.T) {
+	recorder := ActivisionRecorder{}
+	recorder.ObserveCall(&activision_providers.Call{Endpoint: "scrape_test", StatusCode: 200, Attempts: 3}, nil, 300*time.Millisecond)
+	recorder.ObserveCall(&activision_providers.Call{Endpoint: "scrape_test", CacheHit: true}, nil, time.Millisecond)
+	recorder.ObserveCall(&activision_providers.Call{Endpoint: "scrape_test", StatusCode: 200},
+		&activision.ActivisionErrorResponse{Message: "Error: invalid game id\n", StatusCode: 400}, 2*time.Second)
+
+	assertLines(t, scrape(t),
+		"# TYPE warzone_activision_requests_total counter",
+		`warzone_activision_requests_total{endpoint="scrape_test",outcome="success"} 1`,
+		`warzone_activision_requests_total{endpoint="scrape_test",outcome="cache_hit"} 1`,
+		`warzone_activision_requests_total{endpoint="scrape_test",outcome="invalid_input"} 1`,
+		`warzone_activision_cache_hits_total{endpoint="scrape_test"} 1`,
+		`warzone_activision_retries_total{endpoint="scrape_test"} 2`,
+		`warzone_activision_upstream_responses_total{endpoint="scrape_test",status="200"} 2`,
+		"# TYPE warzone_activision_request_duration_seconds histogram",
+		`warzone_activision_request_duration_seconds_bucket{endpoint="scrape_test",le="0.25"} 0`,
+		`warzone_activision_request_duration_seconds_bucket{endpoint="scrape_test",le="0.5"} 1`,
+		`warzone_activision_request_duration_seconds_bucket{endpoint="scrape_test",le="+Inf"} 2`,
+		`warzone_activision_request_duration_seconds_count{endpoint="scrape_test"} 2`,
+		"# TYPE warzone_activision_token_pool_capacity gauge",
+		"warzone_activision_token_pool_capacity 1",
+	)
+}
+
+func TestTokenPoolRejected(t *testing.T) {
+	recorder := ActivisionRecorder{}
+	defer tokensRejected.Store(false)
+
+	recorder.ObserveCall(&activision_providers.Call{Endpoint: "tokens_test", StatusCode: http.StatusForbidden},
+		&activision.ActivisionErrorResponse{Message: "Error: forbidden\n", StatusCode: 500}, time.Millisecond)
+	if !tokensRejected.Load() {
+		t.Fatal("expected the tokens to be marked as rejected after 403")
+	}
+	assertLines(t, scrape(t), "warzone_activision_token_pool_available 0")
+
+	recorder.ObserveCall(&activision_providers.Call{Endpoint: "tokens_test", StatusCode: http.StatusOK}, nil, time.Millisecond)
+	if tokensRejected.Load() {
+		t.Fatal("expected successful response to clear the rejection")
+	}
+}
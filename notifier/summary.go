@@ -0,0 +1,95 @@
+// Package notifier post a summary of the new matches of the tracked squads into Discord or Slack webhooks,
+// the notifier implements the watcher.Sink interface so it can be registered in the watch mode.
+
+package notifier
+
+import (
+	"sort"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+)
+
+// PlayerSummary is the stats of a single squad member that are shown in the message.
+type PlayerSummary struct {
+	Username string   `json:"username"`
+	Kills    float64  `json:"kills"`
+	Deaths   float64  `json:"deaths"`
+	Assists  float64  `json:"assists"`
+	Damage   float64  `json:"damage"`
+	Awards   []string `json:"awards"` // the friendly names of the awards in the order they were earned
+}
+
+// Summary is the data of the match that is passed to the message template.
+type Summary struct {
+	Squad     string          `json:"squad"`
+	MatchID   string          `json:"matchID"`
+	Mode      string          `json:"mode"`
+	Map       string          `json:"map"`
+	Start     time.Time       `json:"start"`
+	Placement float64         `json:"placement"`
+	Won       bool            `json:"won"`
+	Kills     float64         `json:"kills"`  // the total kills of the squad members
+	Damage    float64         `json:"damage"` // the total damage of the squad members
+	TopKills  float64         `json:"topKills"`
+	Players   []PlayerSummary `json:"players"` // sorted by kills
+}
+
+// SummaryFromEvent build the summary of the match event of the watcher.
+func SummaryFromEvent(event watcher.MatchEvent) Summary {
+	players := make([]PlayerSummary, 0, len(event.Players))
+	for _, p := range event.Players {
+		players = append(players, PlayerSummary{Username: p.Username, Kills: p.Stats.Kills, Deaths: p.Stats.Deaths,
+			Assists: p.Stats.Assists, Damage: p.Stats.DamageDone, Awards: awardNames(p.Awards)})
+	}
+	return newSummary(event.Squad, event.Match.MatchID, event.Match.Mode, event.Match.Map, event.Match.UtcStartSeconds,
+		event.Match.PlayerStats.TeamPlacement, players)
+}
+
+// SummaryFromMatch build the summary of a single player match from the last games response.
+func SummaryFromMatch(username string, m activision.Match) Summary {
+	player := PlayerSummary{Username: username, Kills: m.PlayerStats.Kills, Deaths: m.PlayerStats.Deaths,
+		Assists: m.PlayerStats.Assists, Damage: m.PlayerStats.DamageDone, Awards: awardNames(m.Player.Awards)}
+	return newSummary(username, m.MatchID, m.Mode, m.Map, m.UtcStartSeconds, m.PlayerStats.TeamPlacement, []PlayerSummary{player})
+}
+
+// SummaryFromGame build the summary of the team of the player with the given uno from the full match response.
+func SummaryFromGame(r *activision.SpecificGameStatsResponse, uno string) (Summary, error) {
+	team, ok := r.TeamOf(uno)
+	if !ok {
+		return Summary{}, activision.NewValidationError("uno", uno, "the player did not play in this match")
+	}
+	var self activision.PlayerGeneralStatsFromSpecificGame
+	players := make([]PlayerSummary, 0, len(team.Members))
+	for _, p := range team.Members {
+		if p.Player.Uno == uno {
+			self = p
+		}
+		players = append(players, PlayerSummary{Username: p.Player.Username, Kills: p.PlayerStats.Kills, Deaths: p.PlayerStats.Deaths,
+			Assists: p.PlayerStats.Assists, Damage: p.PlayerStats.DamageDone, Awards: awardNames(p.Player.Awards)})
+	}
+	return newSummary(self.Player.Username, self.MatchID, self.Mode, self.Map, self.UtcStartSeconds, team.Placement, players), nil
+}
+
+func newSummary(squad string, matchID string, mode string, mapName string, start float64, placement float64, players []PlayerSummary) Summary {
+	sort.SliceStable(players, func(i, j int) bool { return players[i].Kills > players[j].Kills })
+	s := Summary{Squad: squad, MatchID: matchID, Mode: mode, Map: activision.MapName(mapName), Start: time.Unix(int64(start), 0).UTC(),
+		Placement: placement, Won: placement == 1, Players: players}
+	for _, p := range players {
+		s.Kills += p.Kills
+		s.Damage += p.Damage
+		if p.Kills > s.TopKills {
+			s.TopKills = p.Kills
+		}
+	}
+	return s
+}
+
+func awardNames(awards activision.Awards) []string {
+	var names []string
+	for _, key := range awards.Keys() {
+		names = append(names, activision.AwardName(key))
+	}
+	return names
+}
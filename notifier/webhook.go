@@ -0,0 +1,166 @@
+// This file contains the webhooks configuration and the Notifier that post the messages, example of webhooks file:
+//
+//	[
+//	  {"url": "https://discord.com/api/webhooks/...", "format": "discord", "rules": {"onlyWins": true, "minKills": 15}},
+//	  {"url": "https://hooks.slack.com/services/...", "format": "slack", "rules": {"topPlacement": 5}, "template": "{{.Squad}} got #{{.Placement}}"}
+//	]
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/NivNagli/WarzoneSquad_Go/clients/restclient"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/watcher"
+)
+
+// The payload formats that the webhooks accept.
+const (
+	FormatDiscord = "discord"
+	FormatSlack   = "slack"
+)
+
+// DefaultTemplate is the message that is used when the webhook does not set its own template, the template receive Summary.
+const DefaultTemplate = `{{if .Won}}:trophy: {{end}}{{.Squad}} finished #{{.Placement}} in {{.Mode}}{{if .Map}} on {{.Map}}{{end}} with {{.Kills}} kills and {{.Damage}} damage
+{{range .Players}}- {{.Username}}: {{.Kills}} kills, {{.Deaths}} deaths, {{.Damage}} damage
+{{end}}`
+
+// Rules decide which matches are posted, a match is posted when it pass at least one of the rules that are set,
+// that way {"onlyWins": true, "minKills": 15} post the wins and also the games that someone dropped 15 kills.
+// When no rule is set every match is posted.
+type Rules struct {
+	OnlyWins     bool    `json:"onlyWins"`
+	TopPlacement float64 `json:"topPlacement"` // post only when the squad finished in this place or better, 0 to disable
+	MinKills     float64 `json:"minKills"`     // post only when one of the squad members got at least this kills, 0 to disable
+}
+
+// Match return true when the summary should be posted according to the rules.
+func (r Rules) Match(s Summary) bool {
+	if !r.OnlyWins && r.TopPlacement <= 0 && r.MinKills <= 0 {
+		return true
+	}
+	if r.OnlyWins && s.Won {
+		return true
+	}
+	if r.TopPlacement > 0 && s.Placement > 0 && s.Placement <= r.TopPlacement {
+		return true
+	}
+	return r.MinKills > 0 && s.TopKills >= r.MinKills
+}
+
+// Webhook is the configuration of a single webhook.
+type Webhook struct {
+	URL      string `json:"url"`
+	Format   string `json:"format"`
+	Template string `json:"template"`
+	Rules    Rules  `json:"rules"`
+}
+
+// LoadWebhooks read the webhooks file.
+func LoadWebhooks(path string) ([]Webhook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var webhooks []Webhook
+	if err := json.Unmarshal(data, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+type webhook struct {
+	Webhook
+	template *template.Template
+}
+
+// Notifier post the summaries into the webhooks, the Notifier implements watcher.Sink.
+type Notifier struct {
+	webhooks []webhook
+}
+
+// New validate the webhooks, parse their templates and return Notifier that use them.
+func New(webhooks []Webhook) (*Notifier, error) {
+	n := &Notifier{}
+	for _, w := range webhooks {
+		if w.URL == "" {
+			return nil, activision.NewValidationError("url", w.URL, "webhook url is required")
+		}
+		if w.Format == "" {
+			w.Format = FormatDiscord
+		}
+		if w.Format != FormatDiscord && w.Format != FormatSlack {
+			return nil, activision.NewValidationError("format", w.Format, "webhook format must be discord or slack")
+		}
+		text := w.Template
+		if text == "" {
+			text = DefaultTemplate
+		}
+		t, err := template.New(w.Format).Parse(text)
+		if err != nil {
+			return nil, activision.NewValidationError("template", text, err.Error())
+		}
+		n.webhooks = append(n.webhooks, webhook{Webhook: w, template: t})
+	}
+	return n, nil
+}
+
+// Notify post the summary of the watcher event.
+func (n *Notifier) Notify(ctx context.Context, event watcher.MatchEvent) error {
+	return n.Send(ctx, SummaryFromEvent(event))
+}
+
+// Send post the summary to every webhook that its rules match the summary, a failure of one webhook does not stop
+// the others and the first error is returned.
+func (n *Notifier) Send(ctx context.Context, s Summary) error {
+	var firstErr error
+	for _, w := range n.webhooks {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !w.Rules.Match(s) {
+			continue
+		}
+		if err := w.send(ctx, s); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (w webhook) send(ctx context.Context, s Summary) error {
+	var message strings.Builder
+	if err := w.template.Execute(&message, s); err != nil {
+		return err
+	}
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json")
+	response, err := restclient.Post(ctx, w.URL, payload(w.Format, message.String()), headers)
+	if err != nil {
+		// The url of the webhook contains its secret token so we don't return the error of the http client that include it.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to post to the %s webhook", w.Format)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("the %s webhook returned status %d", w.Format, response.StatusCode)
+	}
+	return nil
+}
+
+// payload build the request body in the format of the webhook.
+func payload(format string, message string) interface{} {
+	if format == FormatSlack {
+		return map[string]string{"text": message}
+	}
+	return map[string]string{"content": message}
+}
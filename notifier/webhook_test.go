@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// webhookServer record the json bodies that was posted to it.
+type webhookServer struct {
+	*httptest.Server
+	mutex  sync.Mutex
+	bodies []map[string]string
+}
+
+func newWebhookServer(t *testing.T, status int) *webhookServer {
+	s := &webhookServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected json POST, got %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode the body: %s", err)
+		}
+		s.mutex.Lock()
+		s.bodies = append(s.bodies, body)
+		s.mutex.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *webhookServer) received() []map[string]string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]map[string]string(nil), s.bodies...)
+}
+
+func testSummary(placement float64, kills ...float64) Summary {
+	players := make([]PlayerSummary, len(kills))
+	for i, k := range kills {
+		players[i] = PlayerSummary{Username: "player" + string(rune('A'+i)), Kills: k, Damage: k * 250}
+	}
+	return newSummary("the boys", "1", "br_brquads", "mp_don4", 1638419782, placement, players)
+}
+
+func TestWebhookPayloads(t *testing.T) {
+	discord := newWebhookServer(t, http.StatusNoContent)
+	slack := newWebhookServer(t, http.StatusOK)
+	n, err := New([]Webhook{
+		{URL: discord.URL},
+		{URL: slack.URL, Format: FormatSlack, Template: "{{.Squad}} got #{{.Placement}} with {{.Kills}} kills"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Send(context.Background(), testSummary(1, 6, 3)); err != nil {
+		t.Fatal(err)
+	}
+
+	bodies := discord.received()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 discord message, got %d", len(bodies))
+	}
+	content := bodies[0]["content"]
+	for _, expected := range []string{":trophy: the boys finished #1", "with 9 kills and 2250 damage", "- playerA: 6 kills"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("expected the discord message to contain %q, got %q", expected, content)
+		}
+	}
+	if _, ok := bodies[0]["text"]; ok {
+		t.Error("the discord payload must not have the slack text field")
+	}
+
+	bodies = slack.received()
+	if len(bodies) != 1 {
+		t.Fatalf("expected 1 slack message, got %d", len(bodies))
+	}
+	if bodies[0]["text"] != "the boys got #1 with 9 kills" {
+		t.Errorf("unexpected slack message %q", bodies[0]["text"])
+	}
+}
+
+func TestWebhookRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    Rules
+		summary  Summary
+		expected bool
+	}{
+		{"no rules post everything", Rules{}, testSummary(40, 1), true},
+		{"only wins with win", Rules{OnlyWins: true}, testSummary(1, 1), true},
+		{"only wins with second place", Rules{OnlyWins: true}, testSummary(2, 1), false},
+		{"top 5 with fifth place", Rules{TopPlacement: 5}, testSummary(5, 1), true},
+		{"top 5 with sixth place", Rules{TopPlacement: 5}, testSummary(6, 1), false},
+		{"top 5 without placement", Rules{TopPlacement: 5}, testSummary(0, 1), false},
+		{"kill threshold reached by one player", Rules{MinKills: 10}, testSummary(30, 4, 10), true},
+		{"kill threshold reached only by the squad", Rules{MinKills: 10}, testSummary(30, 6, 6), false},
+		{"any of the rules", Rules{OnlyWins: true, MinKills: 10}, testSummary(12, 12), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newWebhookServer(t, http.StatusNoContent)
+			n, err := New([]Webhook{{URL: server.URL, Rules: test.rules}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := n.Send(context.Background(), test.summary); err != nil {
+				t.Fatal(err)
+			}
+			if posted := len(server.received()) == 1; posted != test.expected {
+				t.Errorf("expected posted to be %t", test.expected)
+			}
+		})
+	}
+}
+
+func TestWebhookErrors(t *testing.T) {
+	failing := newWebhookServer(t, http.StatusBadRequest)
+	working := newWebhookServer(t, http.StatusNoContent)
+	n, err := New([]Webhook{{URL: failing.URL}, {URL: working.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = n.Send(context.Background(), testSummary(1, 1))
+	if err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("expected the status error of the first webhook, got %v", err)
+	}
+	if len(working.received()) != 1 {
+		t.Error("a failing webhook must not stop the others")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := n.Send(ctx, testSummary(1, 1)); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if _, err := New([]Webhook{{URL: working.URL, Format: "teams"}}); err == nil {
+		t.Error("expected validation error for unknown format")
+	}
+}
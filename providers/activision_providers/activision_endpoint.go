@@ -0,0 +1,250 @@
+// This file contains the generic pipeline that all the provider functions use in order to send the request to the official API,
+// every endpoint is described by Endpoint object (how to build the url and how to check the response) and the Execute function
+// is doing the rest: headers -> url -> restclient.Get -> decode the body -> status check -> schema check.
+
+// The request is passing through the registered middlewares before it sent, that way logging, metrics, caching and retries
+// are implemented once and behave the same for all the endpoints.
+
+package activision_providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/clients/restclient"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// Endpoint describe a single endpoint of the official API, Req is the request domain object and Resp is the response domain object.
+// URL is required, Check is called after the body was decoded in order to find failures that the official API return with 200 status code,
+// and Complete can be used to fill fields of the result that not come from the official API.
+// Fixture is the name of the saved response that the live response is compared with in order to find schema drift.
+// CacheTTL is the time that the cache middleware keep the results of the endpoint, 0 use the ttl of the middleware.
+type Endpoint[Req any, Resp any] struct {
+	Name     string
+	Fixture  string
+	CacheTTL time.Duration
+	URL      func(r Req) (string, error)
+	Check    func(r Req, result *Resp) error
+	Complete func(r Req, result *Resp)
+}
+
+// Call holds the details of a single request while it pass through the middlewares,
+// Result is a pointer to the response domain object that the body will be decoded into.
+type Call struct {
+	RequestID  string
+	Endpoint   string
+	Fixture    string
+	URL        string
+	Request    interface{}
+	Result     interface{}
+	CacheTTL   time.Duration // the endpoint CacheTTL
+	StatusCode int           // the http status code that we received from the official API
+	Attempts   int           // the number of times the request was sent, more then 1 when the retry middleware sent it again
+	CacheHit   bool          // true when the result was taken from the cache middleware without sending the request
+	Retryable  bool          // true when the failure is temporary and the request can be sent again
+
+	check func() error
+}
+
+// Handler send the call and fill the call Result, Middleware wrap a Handler in order to add behaviour before and after the request.
+type Handler func(c *Call) error
+type Middleware func(next Handler) Handler
+
+// restGet send the http requests of the pipeline, it is restclient.Get unless changed by the tests.
+var restGet = restclient.Get
+
+var (
+	middlewaresMutex sync.RWMutex
+	middlewares      = []Middleware{LoggingMiddleware}
+)
+
+// Use append middlewares to the pipeline, the first middleware that registered is the outer one.
+// Use should be called when the application starts before any request is sent.
+func Use(m ...Middleware) {
+	middlewaresMutex.Lock()
+	defer middlewaresMutex.Unlock()
+	middlewares = append(middlewares, m...)
+}
+
+// SetMiddlewares replace all the middlewares of the pipeline (including the default logging middleware).
+func SetMiddlewares(m ...Middleware) {
+	middlewaresMutex.Lock()
+	defer middlewaresMutex.Unlock()
+	middlewares = append([]Middleware(nil), m...)
+}
+
+func buildHandler() Handler {
+	middlewaresMutex.RLock()
+	defer middlewaresMutex.RUnlock()
+	handler := Handler(send)
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Execute send the request of the given endpoint and return the decoded response,
+// the errors that returned are ActivisionErrorResponse or ValidationError (when the url can't be created from the request).
+func Execute[Req any, Resp any](e Endpoint[Req, Resp], r Req) (*Resp, error) {
+	requestUrl, err := e.URL(r)
+	if err != nil {
+		return nil, err
+	}
+	result := new(Resp)
+	call := &Call{RequestID: newRequestID(), Endpoint: e.Name, Fixture: e.Fixture, URL: requestUrl, Request: r, Result: result, CacheTTL: e.CacheTTL}
+	call.check = func() error {
+		if e.Check == nil {
+			return nil
+		}
+		return e.Check(r, result)
+	}
+	if err := buildHandler()(call); err != nil {
+		return nil, err
+	}
+	if e.Complete != nil {
+		e.Complete(r, result)
+	}
+	return result, nil
+}
+
+// send is the inner handler of the pipeline, he is the one that actually send the request to the official API.
+func send(c *Call) error {
+	l := callLogger(c)
+	c.Attempts++
+	// First we create the headers for our request that will have the tokens from the environment variables and the user-agent header
+	// in case we don't find the environment variables we will return error.
+	headers, err := AddHeadersForActivisionRequest()
+	if err != nil {
+		l.Error("failed to set the request headers", slog.String("error", err.Error()))
+		return &activision.ActivisionErrorResponse{Message: "Error: Failed to set the headers request for " + c.Endpoint + " request\n", StatusCode: 500}
+	}
+	// In case of successful request we will get the *http.Response object and err == nil, in the case of failure we will receive nil and the err.
+	response, err := restGet(c.URL, nil, *headers)
+	if err != nil {
+		// The url error contains the full url, we log only the cause because the url may contain username that should be redacted.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		l.Warn("failed to send the request to activision API", slog.String("error", err.Error()))
+		c.Retryable = true
+		return &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
+	}
+	// We must to close the response.Body object when we finish to work on him.
+	defer response.Body.Close()
+	c.StatusCode = response.StatusCode
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500 {
+		l.Warn("activision API returned error status code", slog.Int("status", response.StatusCode))
+		c.Retryable = true
+		return &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
+	}
+	// The body is decoded while it is read instead of reading all of it into memory first, the full match responses
+	// of 150 players lobby are very large. The limited reader stop the decoding when the body is larger then the limit.
+	// We keep a copy of the payload only when the response is compared with the fixture (see schemaNeedsPayload).
+	body := &limitedReader{reader: response.Body, remaining: maxResponseSize() + 1}
+	var payload *bytes.Buffer
+	var reader io.Reader = body
+	if schemaNeedsPayload(c) {
+		payload = &bytes.Buffer{}
+		reader = io.TeeReader(body, payload)
+	}
+	// Because activision API doesn't use status code to indicate if the request failed because of an invalid player details,
+	// after the decoding we run the endpoint Check function that look at the status string that comes from the response body.
+	if err := decodeBody(reader, c.Result); err != nil {
+		if body.exceeded {
+			l.Error("response is larger then the limit", slog.Int64("limit_bytes", maxResponseSize()))
+			return &activision.ActivisionErrorResponse{Message: fmt.Sprintf("Error: activision API response is larger then the limit of %d bytes\n", maxResponseSize()), StatusCode: 502}
+		}
+		// in this case we need to check what are the differences between the response domain object to the response that we receive from the official API.
+		l.Error("failed to decode the response", slog.Int("status", c.StatusCode), slog.String("error", err.Error()))
+		return &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
+	}
+	if err := c.check(); err != nil {
+		return err
+	}
+	// Finally we make sure that the response structure is still the one that our domain objects built for.
+	var raw []byte
+	if payload != nil {
+		raw = payload.Bytes()
+	}
+	return checkSchema(c, raw)
+}
+
+/***************************************** Help functions for reading the response body *****************************************/
+
+// DefaultMaxResponseSize is the default limit of the response body size, the largest response that i saw (full match of 150 players)
+// was a little more then 1MB so this leave a lot of space.
+const DefaultMaxResponseSize int64 = 32 << 20
+
+var maxResponseBytes = DefaultMaxResponseSize
+
+// SetMaxResponseSize change the maximum size in bytes of the response body that we agree to decode, size <= 0 reset it to the default.
+// SetMaxResponseSize should be called when the application starts before any request is sent.
+func SetMaxResponseSize(size int64) {
+	if size <= 0 {
+		size = DefaultMaxResponseSize
+	}
+	maxResponseBytes = size
+}
+
+func maxResponseSize() int64 {
+	return maxResponseBytes
+}
+
+// decodeBody decode the body into the result, response objects that implement the StreamDecoder interface
+// decode their large arrays element by element.
+func decodeBody(reader io.Reader, result interface{}) error {
+	decoder := json.NewDecoder(reader)
+	if stream, ok := result.(activision.StreamDecoder); ok {
+		return stream.DecodeStream(decoder)
+	}
+	return decoder.Decode(result)
+}
+
+var errResponseTooLarge = errors.New("response body is too large")
+
+// limitedReader return errResponseTooLarge once more then the allowed bytes was read, unlike io.LimitReader that return io.EOF
+// and make the decoder think that the body was cut.
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		l.exceeded = true
+		return 0, errResponseTooLarge
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.reader.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining <= 0 {
+		l.exceeded = true
+		return n, errResponseTooLarge
+	}
+	return n, err
+}
+
+/***************************************** Help functions for the endpoints checks *****************************************/
+
+// checkStatus return error when the official API response status is "error", that can happen if the tokens expired or if the user
+// gave us invalid username or platform.
+// The error is logged by the LoggingMiddleware with the rest of the request details.
+func checkStatus(status string) error {
+	if status == "error" {
+		return &activision.ActivisionErrorResponse{Message: "Error: invalid player details make sure you have public profile\nif you do have public profile contact us with error code NN97\n", StatusCode: 500}
+	}
+	return nil
+}
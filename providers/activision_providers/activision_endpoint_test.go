@@ -0,0 +1,103 @@
+package activision_providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/config"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+const fixturesDir = "../../domain/activision/OfficialResponsesFromActiApi/"
+
+// newFixtureServer serve the saved full match response (without the postman wrapper) as the official API would return it,
+// and set fake tokens so send can build the request headers.
+func newFixtureServer(tb testing.TB) *httptest.Server {
+	tb.Helper()
+	data, err := os.ReadFile(fixturesDir + activision.FixtureGameByID)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		tb.Fatal(err)
+	}
+	payload := wrapper["data"]
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(payload)
+	}))
+	tb.Cleanup(server.Close)
+
+	atkn, cookie, expiry := config.ATKN, config.ACT_SSO_COOKIE, config.ACT_SSO_COOKIE_EXPIRY
+	config.ATKN, config.ACT_SSO_COOKIE, config.ACT_SSO_COOKIE_EXPIRY = "atkn", "cookie", "1"
+	tb.Cleanup(func() { config.ATKN, config.ACT_SSO_COOKIE, config.ACT_SSO_COOKIE_EXPIRY = atkn, cookie, expiry })
+	return server
+}
+
+func sendGameByID(tb testing.TB, url string) *activision.SpecificGameStatsResponse {
+	result := &activision.SpecificGameStatsResponse{}
+	c := &Call{Endpoint: gameStatsByIDEndpoint.Name, Fixture: activision.FixtureGameByID, URL: url, Result: result, check: func() error { return nil }}
+	if err := send(c); err != nil {
+		tb.Fatal(err)
+	}
+	return result
+}
+
+func TestSchemaSampling(t *testing.T) {
+	defer SetSchemaMode(SchemaWarn)
+	defer SetSchemaSampleRate(0)
+	SetSchemaSampleRate(3)
+	sampled := 0
+	for i := 0; i < 7; i++ {
+		if schemaNeedsPayload(&Call{Endpoint: "sampled", Fixture: activision.FixtureGameByID}) {
+			sampled++
+		}
+	}
+	// The calls 1, 4 and 7 are compared with the fixture.
+	if sampled != 3 {
+		t.Errorf("expected 3 sampled calls out of 7, got %d", sampled)
+	}
+	if schemaNeedsPayload(&Call{Endpoint: "no fixture"}) {
+		t.Error("calls without fixture must not keep the payload in warn mode")
+	}
+	SetSchemaMode(SchemaStrict)
+	if !schemaNeedsPayload(&Call{Endpoint: "no fixture"}) {
+		t.Error("strict mode must keep the payload of every call")
+	}
+	SetSchemaMode(SchemaOff)
+	if schemaNeedsPayload(&Call{Endpoint: "off", Fixture: activision.FixtureGameByID}) {
+		t.Error("off mode must not keep the payload")
+	}
+}
+
+func TestSendGameByID(t *testing.T) {
+	server := newFixtureServer(t)
+	result := sendGameByID(t, server.URL)
+	if result.Status != "success" || len(result.Data.AllPlayers) != 151 {
+		t.Fatalf("expected success with 151 players, got %q with %d players", result.Status, len(result.Data.AllPlayers))
+	}
+}
+
+// BenchmarkSendGameByID send the full match request through send with the default schema mode, the way the providers do.
+func BenchmarkSendGameByID(b *testing.B) {
+	server := newFixtureServer(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sendGameByID(b, server.URL)
+	}
+}
+
+// BenchmarkSendGameByIDCompareAll is the same request when every response is compared with the fixture.
+func BenchmarkSendGameByIDCompareAll(b *testing.B) {
+	server := newFixtureServer(b)
+	SetSchemaSampleRate(1)
+	defer SetSchemaSampleRate(0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sendGameByID(b, server.URL)
+	}
+}
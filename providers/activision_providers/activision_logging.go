@@ -0,0 +1,83 @@
+// This file holds the structured logger of the providers layer, every request that pass through the pipeline get its own
+// request ID and all the log records of the request carry the same attributes (request_id, endpoint, platform, username)
+// so the logs of a single request can be found together.
+
+package activision_providers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+var (
+	loggerMutex     sync.RWMutex
+	logger          = slog.Default()
+	redactUsernames bool
+)
+
+// SetLogger replace the logger of the providers layer, nil reset it to slog.Default().
+func SetLogger(l *slog.Logger) {
+	loggerMutex.Lock()
+	defer loggerMutex.Unlock()
+	if l == nil {
+		l = slog.Default()
+	}
+	logger = l
+}
+
+// SetRedactUsernames decide if the usernames are written to the logs as is or redacted (only the first letter is kept),
+// the username is also removed from the url that we log.
+func SetRedactUsernames(redact bool) {
+	loggerMutex.Lock()
+	defer loggerMutex.Unlock()
+	redactUsernames = redact
+}
+
+func currentLogger() (*slog.Logger, bool) {
+	loggerMutex.RLock()
+	defer loggerMutex.RUnlock()
+	return logger, redactUsernames
+}
+
+// callLogger return logger with the attributes of the call.
+func callLogger(c *Call) *slog.Logger {
+	l, redact := currentLogger()
+	attrs := []any{slog.String("request_id", c.RequestID), slog.String("endpoint", c.Endpoint)}
+	url := c.URL
+	switch r := c.Request.(type) {
+	case activision.ActivisionRequest:
+		username := r.GetUsername()
+		if redact {
+			url = strings.ReplaceAll(url, EscapeUsername(strings.TrimSpace(username)), "REDACTED")
+			username = redactUsername(username)
+		}
+		attrs = append(attrs, slog.String("platform", r.GetPlatform()), slog.String("username", username))
+	case activision.SpecificGameStatsRequest:
+		attrs = append(attrs, slog.String("game_id", r.GameID))
+	}
+	attrs = append(attrs, slog.String("url", url))
+	return l.With(attrs...)
+}
+
+// redactUsername keep only the first letter of the username, for example "nivGolanigo#1234" will be "n***".
+func redactUsername(username string) string {
+	runes := []rune(strings.TrimSpace(username))
+	if len(runes) == 0 {
+		return ""
+	}
+	return string(runes[0]) + "***"
+}
+
+// newRequestID return random 16 characters hex string.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,229 @@
+// This file contains the middlewares that can be registered to the requests pipeline with the Use function.
+
+package activision_providers
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoggingMiddleware write log record for every request that sent to the official API with the upstream status code and the duration,
+// successful requests are logged in info level and failed requests in warn level. This middleware is registered by default.
+func LoggingMiddleware(next Handler) Handler {
+	return func(c *Call) error {
+		start := time.Now()
+		err := next(c)
+		attrs := []any{
+			slog.Int("status", c.StatusCode),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("attempts", c.Attempts),
+			slog.Bool("cache_hit", c.CacheHit),
+		}
+		l := callLogger(c)
+		if err != nil {
+			l.Warn("activision request failed", append(attrs, slog.String("error", strings.TrimSpace(err.Error())))...)
+		} else {
+			l.Info("activision request finished", attrs...)
+		}
+		return err
+	}
+}
+
+// RetryMiddleware send the request again when it failed with temporary error (network error, 429 or 5xx from the official API),
+// the wait between the attempts is doubled after each attempt.
+func RetryMiddleware(attempts int, backoff time.Duration) Middleware {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return func(next Handler) Handler {
+		return func(c *Call) error {
+			wait := backoff
+			var err error
+			for i := 0; i < attempts; i++ {
+				c.Retryable = false
+				if err = next(c); err == nil || !c.Retryable {
+					return err
+				}
+				if i < attempts-1 {
+					time.Sleep(wait)
+					wait *= 2
+				}
+			}
+			return err
+		}
+	}
+}
+
+// MetricsRecorder receive the result of every call that passed through the MetricsMiddleware.
+type MetricsRecorder interface {
+	ObserveCall(c *Call, err error, duration time.Duration)
+}
+
+// MetricsMiddleware report every call to the given recorder, it should be registered before the cache and the retry middlewares
+// so the recorder will see the cache hits and the number of attempts.
+func MetricsMiddleware(recorder MetricsRecorder) Middleware {
+	return func(next Handler) Handler {
+		return func(c *Call) error {
+			start := time.Now()
+			err := next(c)
+			recorder.ObserveCall(c, err, time.Since(start))
+			return err
+		}
+	}
+}
+
+// DefaultCacheEntries is the default number of results that the cache middleware keep, a full match is about 150 players
+// so 500 results are few tens of megabytes.
+const DefaultCacheEntries = 500
+
+type cacheEntry struct {
+	value   reflect.Value
+	expires time.Time
+}
+
+// CacheMiddleware keep the successful results in memory by the request url for the given ttl, or for the endpoint CacheTTL when it is set.
+// It should be registered after the metrics middleware and before the retry and the rate limit middlewares.
+// At most maxEntries results are kept (maxEntries <= 0 use DefaultCacheEntries), the expired results are swept once every ttl
+// and when the cache is full the result that is the closest to expire is removed.
+// The results are deep copied into the cache and out of it so the callers can change their result (and the slices and the maps
+// inside it) without changing the cache.
+func CacheMiddleware(ttl time.Duration, maxEntries int) Middleware {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheEntries
+	}
+	var mutex sync.Mutex
+	entries := make(map[string]cacheEntry)
+	nextSweep := time.Now().Add(ttl)
+	// store add the entry and make room for it, the caller must hold the mutex.
+	store := func(url string, entry cacheEntry, now time.Time) {
+		if now.After(nextSweep) {
+			for key, e := range entries {
+				if now.After(e.expires) {
+					delete(entries, key)
+				}
+			}
+			nextSweep = now.Add(ttl)
+		}
+		if _, ok := entries[url]; !ok && len(entries) >= maxEntries {
+			var oldest string
+			for key, e := range entries {
+				if oldest == "" || e.expires.Before(entries[oldest].expires) {
+					oldest = key
+				}
+			}
+			delete(entries, oldest)
+		}
+		entries[url] = entry
+	}
+	return func(next Handler) Handler {
+		return func(c *Call) error {
+			mutex.Lock()
+			entry, ok := entries[c.URL]
+			if ok && time.Now().After(entry.expires) {
+				delete(entries, c.URL)
+				ok = false
+			}
+			mutex.Unlock()
+			if ok {
+				reflect.ValueOf(c.Result).Elem().Set(deepCopy(entry.value))
+				c.CacheHit = true
+				return nil
+			}
+			if err := next(c); err != nil {
+				return err
+			}
+			copied := deepCopy(reflect.ValueOf(c.Result).Elem())
+			entryTTL := ttl
+			if c.CacheTTL > 0 {
+				entryTTL = c.CacheTTL
+			}
+			now := time.Now()
+			mutex.Lock()
+			store(c.URL, cacheEntry{value: copied, expires: now.Add(entryTTL)}, now)
+			mutex.Unlock()
+			return nil
+		}
+	}
+}
+
+// deepCopy return a copy of the value that does not share pointers, slices or maps with it.
+// Unexported struct fields are copied as they are because reflect can't set them, our domain objects don't have such fields.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type().Elem())
+		copied.Elem().Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(deepCopy(v.Elem()))
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			copied.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+// RateLimitMiddleware make sure that at least 'interval' pass between the requests that sent to the official API,
+// requests that come faster wait for their turn. It should be registered after the cache middleware so cache hits
+// are not delayed.
+func RateLimitMiddleware(interval time.Duration) Middleware {
+	var mutex sync.Mutex
+	var nextSlot time.Time
+	return func(next Handler) Handler {
+		return func(c *Call) error {
+			mutex.Lock()
+			now := time.Now()
+			if nextSlot.Before(now) {
+				nextSlot = now
+			}
+			wait := nextSlot.Sub(now)
+			nextSlot = nextSlot.Add(interval)
+			mutex.Unlock()
+			time.Sleep(wait)
+			return next(c)
+		}
+	}
+}
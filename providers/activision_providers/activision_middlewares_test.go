@@ -0,0 +1,88 @@
+package activision_providers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+func TestCacheMiddlewareDeepCopy(t *testing.T) {
+	sent := 0
+	handler := CacheMiddleware(time.Minute, 0)(func(c *Call) error {
+		sent++
+		result := c.Result.(*activision.SpecificGameStatsResponse)
+		result.Status = "success"
+		result.Data.AllPlayers = []activision.PlayerGeneralStatsFromSpecificGame{{MatchID: "1"}}
+		result.Data.AllPlayers[0].PlayerStats.Objectives = activision.Objectives{activision.ObjectiveReviver: 2}
+		return nil
+	})
+	call := func() *activision.SpecificGameStatsResponse {
+		result := &activision.SpecificGameStatsResponse{}
+		if err := handler(&Call{URL: "https://example.com/match/1", Result: result}); err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	first := call()
+	first.Data.AllPlayers[0].MatchID = "changed"
+	first.Data.AllPlayers[0].PlayerStats.Objectives[activision.ObjectiveReviver] = 100
+
+	second := call()
+	if sent != 1 {
+		t.Fatalf("expected the second call to be a cache hit, the request was sent %d times", sent)
+	}
+	player := second.Data.AllPlayers[0]
+	if player.MatchID != "1" || player.PlayerStats.Objectives[activision.ObjectiveReviver] != 2 {
+		t.Fatalf("the change of the first result changed the cache, got %+v", player)
+	}
+
+	second.Data.AllPlayers[0].PlayerStats.Objectives[activision.ObjectiveReviver] = 50
+	if third := call(); third.Data.AllPlayers[0].PlayerStats.Objectives[activision.ObjectiveReviver] != 2 {
+		t.Fatal("the change of a cache hit result changed the cache")
+	}
+}
+
+func TestCacheMiddlewareLimits(t *testing.T) {
+	sent := make(map[string]int)
+	handler := CacheMiddleware(20*time.Millisecond, 3)(func(c *Call) error {
+		sent[c.URL]++
+		c.Result.(*activision.LastGamesResponse).Status = "success"
+		return nil
+	})
+	call := func(url string, ttl time.Duration) {
+		t.Helper()
+		if err := handler(&Call{URL: url, Result: &activision.LastGamesResponse{}, CacheTTL: ttl}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The match is kept by its own ttl, much longer then the ttl of the middleware.
+	call("match", time.Hour)
+	for i := 0; i < 3; i++ {
+		call(fmt.Sprintf("games/%d", i), 0)
+	}
+	call("match", 0)
+	if sent["match"] != 1 {
+		t.Error("the match must not be removed for the new results, it is the last to expire")
+	}
+	// The cache is limited to 3 results so games/0, the closest to expire, was removed for games/2.
+	call("games/2", 0)
+	call("games/0", 0)
+	if sent["games/0"] != 2 || sent["games/2"] != 1 {
+		t.Errorf("expected only games/0 to be sent again, sent %v", sent)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	call("games/3", 0)
+	call("match", 0)
+	if sent["match"] != 1 {
+		t.Error("the sweep must keep the match because it is not expired")
+	}
+	call("games/2", 0)
+	if sent["games/2"] != 2 {
+		t.Error("expected games/2 to be sent again after it expired")
+	}
+}
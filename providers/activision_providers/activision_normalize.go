@@ -0,0 +1,124 @@
+// This file is responsible for normalizing the username and the platform that we receive from the user before we use them
+// in the official API urls, the user can write the platform in different ways (PSN, playstation, xbox...) and the usernames
+// can contain spaces, unicode and other characters that must be escaped.
+
+package activision_providers
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// The platforms names as the official API expect them.
+const (
+	PlatformPlaystation = "psn"
+	PlatformXbox        = "xbl"
+	PlatformBattlenet   = "battle"
+	PlatformActivision  = "uno"
+)
+
+// platformAliases map every name that we accept from the user (in lower case) to the platform name of the official API.
+var platformAliases = map[string]string{
+	"psn":         PlatformPlaystation,
+	"ps":          PlatformPlaystation,
+	"playstation": PlatformPlaystation,
+	"xbl":         PlatformXbox,
+	"xbox":        PlatformXbox,
+	"xboxlive":    PlatformXbox,
+	"battle":      PlatformBattlenet,
+	"battlenet":   PlatformBattlenet,
+	"battle.net":  PlatformBattlenet,
+	"bnet":        PlatformBattlenet,
+	"uno":         PlatformActivision,
+	"activision":  PlatformActivision,
+	"atvi":        PlatformActivision,
+}
+
+// usernameFormats holds the valid username format of each platform:
+// battle - BattleTag, 3-12 letters or digits that not start with a digit and then '#' with the tag numbers.
+// uno    - Activision ID, the name (can contain spaces and some symbols) and then '#' with the id numbers.
+// psn    - PSN online ID, 3-16 characters of english letters, digits, '-' and '_' that start with a letter.
+// xbl    - Xbox gamertag, up to 15 letters, digits and spaces that not start with a space,
+// the modern gamertags can end with '#' and up to 4 suffix digits.
+var usernameFormats = map[string]*regexp.Regexp{
+	PlatformBattlenet:   regexp.MustCompile(`^[\p{L}\p{M}][\p{L}\p{M}\p{N}]{2,11}#\d{4,8}$`),
+	PlatformActivision:  regexp.MustCompile(`^[\p{L}\p{M}\p{N}][\p{L}\p{M}\p{N} ._\-]{1,31}#\d{4,10}$`),
+	PlatformPlaystation: regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_\-]{2,15}$`),
+	PlatformXbox:        regexp.MustCompile(`^[\p{L}\p{M}\p{N}][\p{L}\p{M}\p{N} ]{0,14}(#\d{1,4})?$`),
+}
+
+var gameIDFormat = regexp.MustCompile(`^\d+$`)
+
+// NormalizedPlayer holds the player details after the normalization, EscapedUsername is the one that should be used in the urls.
+type NormalizedPlayer struct {
+	Username        string
+	Platform        string
+	EscapedUsername string
+}
+
+// NormalizePlatform convert the platform that we received into the platform name of the official API,
+// the comparison is case insensitive so "PSN", "PlayStation" and "psn" will all return "psn".
+func NormalizePlatform(platform string) (string, error) {
+	trimmed := strings.TrimSpace(platform)
+	if len(trimmed) == 0 {
+		return "", activision.NewValidationError("platform", platform, "platform is missing")
+	}
+	normalized, ok := platformAliases[strings.ToLower(trimmed)]
+	if !ok {
+		return "", activision.NewValidationError("platform", platform, "unknown platform, use one of psn, xbl, battle or uno")
+	}
+	return normalized, nil
+}
+
+// NormalizeUsername trim the username and validate it according to the username format of the platform,
+// the platform argument must be already normalized.
+func NormalizeUsername(username string, platform string) (string, error) {
+	trimmed := strings.TrimSpace(username)
+	if len(trimmed) == 0 {
+		return "", activision.NewValidationError("username", username, "username is missing")
+	}
+	format, ok := usernameFormats[platform]
+	if !ok {
+		return "", activision.NewValidationError("platform", platform, "unknown platform, use one of psn, xbl, battle or uno")
+	}
+	if !format.MatchString(trimmed) {
+		if platform == PlatformBattlenet || platform == PlatformActivision {
+			return "", activision.NewValidationError("username", username, "username for "+platform+" must be in the format name#1234")
+		}
+		return "", activision.NewValidationError("username", username, "username is not a valid "+platform+" name")
+	}
+	return trimmed, nil
+}
+
+// EscapeUsername escape the username so it can be used as a single path segment in the official API urls,
+// for example "nivGolanigo#1234" will be converted into "nivGolanigo%231234".
+func EscapeUsername(username string) string {
+	return url.PathEscape(username)
+}
+
+// NormalizeRequest normalize and validate the platform and the username of the ActivisionRequest.
+func NormalizeRequest(r activision.ActivisionRequest) (*NormalizedPlayer, error) {
+	platform, err := NormalizePlatform(r.GetPlatform())
+	if err != nil {
+		return nil, err
+	}
+	username, err := NormalizeUsername(r.GetUsername(), platform)
+	if err != nil {
+		return nil, err
+	}
+	return &NormalizedPlayer{Username: username, Platform: platform, EscapedUsername: EscapeUsername(username)}, nil
+}
+
+// ValidateGameID make sure the game ID contains only digits, that way it can't break the url that we build from it.
+func ValidateGameID(gameID string) error {
+	if len(gameID) == 0 {
+		return activision.NewValidationError("gameID", gameID, "game ID is missing")
+	}
+	if !gameIDFormat.MatchString(gameID) {
+		return activision.NewValidationError("gameID", gameID, "game ID must contain only digits")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package activision_providers
+
+import (
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+func TestNormalizePlatform(t *testing.T) {
+	tests := []struct {
+		platform string
+		want     string
+		ok       bool
+	}{
+		{platform: "psn", want: PlatformPlaystation, ok: true},
+		{platform: "PS", want: PlatformPlaystation, ok: true},
+		{platform: " PlayStation ", want: PlatformPlaystation, ok: true},
+		{platform: "xbl", want: PlatformXbox, ok: true},
+		{platform: "Xbox", want: PlatformXbox, ok: true},
+		{platform: "XboxLive", want: PlatformXbox, ok: true},
+		{platform: "battle", want: PlatformBattlenet, ok: true},
+		{platform: "BattleNet", want: PlatformBattlenet, ok: true},
+		{platform: "Battle.net", want: PlatformBattlenet, ok: true},
+		{platform: "bnet", want: PlatformBattlenet, ok: true},
+		{platform: "uno", want: PlatformActivision, ok: true},
+		{platform: "Activision", want: PlatformActivision, ok: true},
+		{platform: "ATVI", want: PlatformActivision, ok: true},
+		{platform: ""},
+		{platform: "   "},
+		{platform: "steam"},
+		{platform: "ps5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			got, err := NormalizePlatform(tt.platform)
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform string
+		username string
+		ok       bool
+	}{
+		{name: "battletag", platform: PlatformBattlenet, username: "Niv#21234", ok: true},
+		{name: "battletag unicode", platform: PlatformBattlenet, username: "Ñandú#1234", ok: true},
+		{name: "battletag start with digit", platform: PlatformBattlenet, username: "1Niv#1234"},
+		{name: "battletag too short", platform: PlatformBattlenet, username: "Ni#1234"},
+		{name: "battletag without tag", platform: PlatformBattlenet, username: "Niv"},
+		{name: "activision id", platform: PlatformActivision, username: "nivGolanigo#1234567", ok: true},
+		{name: "activision id with spaces and symbols", platform: PlatformActivision, username: "The Boys_1.-x#1234", ok: true},
+		{name: "activision id without id", platform: PlatformActivision, username: "nivGolanigo"},
+		{name: "activision id short number", platform: PlatformActivision, username: "nivGolanigo#123"},
+		{name: "psn", platform: PlatformPlaystation, username: "niv_golan-1", ok: true},
+		{name: "psn start with digit", platform: PlatformPlaystation, username: "1niv"},
+		{name: "psn space", platform: PlatformPlaystation, username: "niv golan"},
+		{name: "psn too long", platform: PlatformPlaystation, username: "abcdefghijklmnopq"},
+		{name: "psn unicode", platform: PlatformPlaystation, username: "nivé"},
+		{name: "xbox classic gamertag", platform: PlatformXbox, username: "Major Nelson", ok: true},
+		{name: "xbox 15 characters", platform: PlatformXbox, username: "abcdefghijklmno", ok: true},
+		{name: "xbox too long", platform: PlatformXbox, username: "abcdefghijklmnop"},
+		{name: "xbox modern gamertag with suffix", platform: PlatformXbox, username: "Niv Golan#1234", ok: true},
+		{name: "xbox modern gamertag short suffix", platform: PlatformXbox, username: "Niv#7", ok: true},
+		{name: "xbox modern gamertag unicode", platform: PlatformXbox, username: "ニヴ#12", ok: true},
+		{name: "xbox suffix too long", platform: PlatformXbox, username: "Niv#12345"},
+		{name: "xbox empty suffix", platform: PlatformXbox, username: "Niv#"},
+		{name: "xbox symbol", platform: PlatformXbox, username: "Niv_Golan"},
+		{name: "trimmed", platform: PlatformPlaystation, username: "  niv_golan  ", ok: true},
+		{name: "empty", platform: PlatformPlaystation, username: "  "},
+		{name: "unknown platform", platform: "steam", username: "niv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeUsername(tt.username, tt.platform)
+			if !tt.ok {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %q", tt.username, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got == "" || got[0] == ' ' || got[len(got)-1] == ' ' {
+				t.Errorf("expected trimmed username, got %q", got)
+			}
+		})
+	}
+}
+
+func TestEscapeUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		want     string
+	}{
+		{username: "nivGolanigo", want: "nivGolanigo"},
+		{username: "nivGolanigo#1234", want: "nivGolanigo%231234"},
+		{username: "Major Nelson", want: "Major%20Nelson"},
+		{username: "Ñandú#1234", want: "%C3%91and%C3%BA%231234"},
+		{username: "a/b?c", want: "a%2Fb%3Fc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			if got := EscapeUsername(tt.username); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeRequest(t *testing.T) {
+	player, err := NormalizeRequest(activision.LastGamesRequest{Username: " Niv Golan#1234 ", Platform: "Xbox"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := NormalizedPlayer{Username: "Niv Golan#1234", Platform: PlatformXbox, EscapedUsername: "Niv%20Golan%231234"}
+	if *player != want {
+		t.Errorf("expected %+v, got %+v", want, *player)
+	}
+	if _, err := NormalizeRequest(activision.LastGamesRequest{Username: "niv", Platform: "steam"}); err == nil {
+		t.Error("expected error for unknown platform")
+	}
+}
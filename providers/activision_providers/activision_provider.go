@@ -1,15 +1,82 @@
 package activision_providers
 
 import (
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"time"
 
-	"github.com/NivNagli/WarzoneSquad_Go/clients/restclient"
 	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
 )
 
+// lastGamesByDateRequest is the request object of the last games by date endpoint, it is the LastGamesRequest with the date that
+// the official API will return the 20 games before.
+type lastGamesByDateRequest struct {
+	activision.LastGamesRequest
+	Date string
+}
+
+/********************************* The official API endpoints *************************************/
+
+// Each endpoint describe only what is different between the requests (the url and the response checks), the sending itself
+// is done by the Execute function in the activision_endpoint.go file.
+var (
+	lastGamesEndpoint = Endpoint[activision.LastGamesRequest, activision.LastGamesResponse]{
+		Name:    "last games stats",
+		Fixture: activision.FixtureLastGames,
+		URL:     func(r activision.LastGamesRequest) (string, error) { return CreateLastGamesStatsUrl(r) },
+		Check: func(r activision.LastGamesRequest, result *activision.LastGamesResponse) error {
+			return checkStatus(result.Status)
+		},
+		Complete: completeLastGames,
+	}
+
+	lastGamesByDateEndpoint = Endpoint[lastGamesByDateRequest, activision.LastGamesResponse]{
+		Name:    "last games stats by date",
+		Fixture: activision.FixtureLastGames,
+		URL:     func(r lastGamesByDateRequest) (string, error) { return CreateLastGamesStatsByDateUrl(r, r.Date) },
+		Check: func(r lastGamesByDateRequest, result *activision.LastGamesResponse) error {
+			return checkStatus(result.Status)
+		},
+		Complete: func(r lastGamesByDateRequest, result *activision.LastGamesResponse) {
+			completeLastGames(r.LastGamesRequest, result)
+		},
+	}
+
+	lifetimeAndWeeklyEndpoint = Endpoint[activision.LifetimeAndWeeklyRequest, activision.LifetimeAndWeeklyResponse]{
+		Name:    "lifetime and weekly stats",
+		Fixture: activision.FixtureLifetimeAndWeekly,
+		URL:     func(r activision.LifetimeAndWeeklyRequest) (string, error) { return CreateLifetimeAndWeeklyUrl(r) },
+		Check: func(r activision.LifetimeAndWeeklyRequest, result *activision.LifetimeAndWeeklyResponse) error {
+			return checkStatus(result.Status)
+		},
+	}
+
+	// A finished match never changes so the cache can keep it much longer then the other responses.
+	gameStatsByIDEndpoint = Endpoint[activision.SpecificGameStatsRequest, activision.SpecificGameStatsResponse]{
+		Name:     "game stats by ID",
+		Fixture:  activision.FixtureGameByID,
+		CacheTTL: 24 * time.Hour,
+		URL:      CreateGetSpecificGameUrl,
+		Check: func(r activision.SpecificGameStatsRequest, result *activision.SpecificGameStatsResponse) error {
+			if err := checkStatus(result.Status); err != nil {
+				return err
+			}
+			// The official API return success status with empty players array for game ID that not exists.
+			if len(result.Data.AllPlayers) == 0 {
+				return &activision.ActivisionErrorResponse{Message: "Error: invalid Game ID received.\n", StatusCode: 400}
+			}
+			return nil
+		},
+	}
+)
+
+// completeLastGames add manually the normalized username and platform for the LastGamesResponse result object,
+// the normalization can't fail here because we already used it when we created the url.
+func completeLastGames(r activision.LastGamesRequest, result *activision.LastGamesResponse) {
+	player, _ := NormalizeRequest(r)
+	result.Username = player.Username
+	result.Platform = player.Platform
+}
+
 /********************************* Functions for getting the last games stats by recent/date/cycles *************************************/
 
 // GetLastGameStats return the response from the last game stats request that sent to the official API.
@@ -18,114 +85,15 @@ import (
 
 // In case in the future activision will change the struct of their response object or the authorization header we will get error from this method, i made a spereated tests
 // of each case here and as of the date [10.6.2022] the response object and authorization headers are defined accordingly.
-// We must make sure that the username and platform that we received here are fully
 func GetLastGamesStats(r activision.LastGamesRequest) (*activision.LastGamesResponse, error) {
-	// First we append the create for our request that will have the tokens from the environment variables and the user-agent header
-	// in case we dont find the environment variables we will return error.
-	headers, err := AddHeadersForActivisionRequest()
-	if err != nil {
-		log.Printf("Error: %s", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to set the headers request for last game stats request\n", StatusCode: 500}
-	}
-	// After we create the headers we creating now the url for the last games stats endpoint from the official API.
-	// In case we received invalid username / platform we will return error.
-	url, err := CreateLastGamesStatsUrl(r)
-	if err != nil {
-		return nil, err
-	}
-	// Now that we the url and the headers we can send the request, we are doing that with the "restclient" Get method which will handle the sending procedure for us,
-	// i implemented the restclient because i want to reduce the repeated code and also to have the option to mock the response result for mocking that will serve us in the tests.
-	// In case of successful request we will get the *http.Response object and err == nil, in the case of failure we will recive nil and the err.
-	response, err := restclient.Get(url, nil, *headers)
-	if err != nil {
-		log.Printf("error when trying to get last games stats from activision API: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
-	}
-	// We must to close the response.Body object when we finish to work on him, so i set defer that will execute when this method scope will close.
-	defer response.Body.Close()
-	// Attempt to read the response body into []byte object.
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		log.Printf("Failed to parse response body: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-	}
-	// Now that we have the response body in []byte format, we can try to unmarshal the response into our LastGamesResponse object, because activision API doesn't use Status code to indicate
-	// if the request failed because of an invalid player details, we need to check the response status string that comes from the response body.
-	// So we have 2 cases which we will return error from this unmarshal: first one is that our LastGamesResponse failed to receive the data from the response body and the second case is that
-	// the data was not found for the given username and platform...
-	var result activision.LastGamesResponse
-	if err := json.Unmarshal(body, &result); err != nil || result.Status == "error" {
-		if err != nil {
-			// in this case we need to check what are the differences between the activision.LastGamesResponse struct to the response that we receive from the official API for the last games endpoint.
-			log.Printf("error when trying to unmarshal last games successful response: %s\n", err.Error())
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-		} else {
-			// The case the request failed that can happen if the tokens expired or if the user gave us invalid username or platform.
-			log.Printf("Error: failed to access to activision API due invalid arguments, ask the user to verify his info and check your activision tokens!")
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: invalid player details make sure you have public profile\nif you do have public profile contact us with error code NN97\n", StatusCode: 500}
-		}
-
-	}
-	// Finally that is the good case, i added manually the username and platform for the LastGamesResponse result object.
-	result.Username = r.Username
-	result.Platform = r.Platform
-	return &result, nil
+	return Execute(lastGamesEndpoint, r)
 }
 
 // GetLastGamesStatsByDate func responsible for return the player last games stats according to date string
 // the represented in utc time format, the result will be according to the last 20 games from the
 // given date string.
 func GetLastGamesStatsByDate(r activision.LastGamesRequest, d string) (*activision.LastGamesResponse, error) {
-	// First we append the create for our request that will have the tokens from the environment variables and the user-agent header
-	// in case we dont find the environment variables we will return error.
-	headers, err := AddHeadersForActivisionRequest()
-	if err != nil {
-		log.Printf("Error: %s", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to set the headers request for last game stats request\n", StatusCode: 500}
-	}
-	// After we create the headers we creating now the url for the last games stats endpoint from the official API.
-	// In case we received invalid username / platform we will return error.
-	url, err := CreateLastGamesStatsByDateUrl(r, d)
-	if err != nil {
-		return nil, err
-	}
-	// Now that we the url and the headers we can send the request, we are doing that with the "restclient" Get method which will handle the sending procedure for us,
-	// i implemented the restclient because i want to reduce the repeated code and also to have the option to mock the response result for mocking that will serve us in the tests.
-	// In case of successful request we will get the *http.Response object and err == nil, in the case of failure we will recive nil and the err.
-	response, err := restclient.Get(url, nil, *headers)
-	if err != nil {
-		log.Printf("error when trying to get last games stats by date from activision API: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
-	}
-	// We must to close the response.Body object when we finish to work on him, so i set defer that will execute when this method scope will close.
-	defer response.Body.Close()
-	// Attempt to read the response body into []byte object.
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		log.Printf("Failed to parse response body: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-	}
-	// Now that we have the response body in []byte format, we can try to unmarshal the response into our LastGamesResponse object, because activision API doesn't use Status code to indicate
-	// if the request failed because of an invalid player details, we need to check the response status string that comes from the response body.
-	// So we have 2 cases which we will return error from this unmarshal: first one is that our LastGamesResponse failed to receive the data from the response body and the second case is that
-	// the data was not found for the given username and platform...
-	var result activision.LastGamesResponse
-	if err := json.Unmarshal(body, &result); err != nil || result.Status == "error" {
-		if err != nil {
-			// in this case we need to check what are the differences between the activision.LastGamesResponse struct to the response that we receive from the official API for the last games endpoint.
-			log.Printf("error when trying to unmarshal last games by date successful response: %s\n", err.Error())
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-		} else {
-			// The case the request failed that can happen if the tokens expired or if the user gave us invalid username or platform.
-			log.Printf("Error: failed to access to activision API due invalid arguments, ask the user to verify his info and check your activision tokens!")
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: invalid player details make sure you have public profile\nif you do have public profile contact us with error code NN97\n", StatusCode: 500}
-		}
-
-	}
-	// Finally that is the good case, i added manually the username and platform for the LastGamesResponse result object.
-	result.Username = r.Username
-	result.Platform = r.Platform
-	return &result, nil
+	return Execute(lastGamesByDateEndpoint, lastGamesByDateRequest{LastGamesRequest: r, Date: d})
 }
 
 // GetLastGamesStatsByCycles will try to fill the player's last games array with more then 20 games
@@ -146,6 +114,11 @@ func GetLastGamesStatsByCycles(r activision.LastGamesRequest, c int) (*activisio
 	// 20 games that occur after him, and then will save the results in order to use them if we didn't
 	// finish all the cycles and for the return value.
 	for i := 0; i < c-1; i++ {
+		// When the player has no more games we can stop before we finish all the cycles.
+		if len(responsesArray[i].Data.Matches) == 0 {
+			matchesArray = matchesArray[:i+1]
+			break
+		}
 		// Reading the last game date that we have for this cycle
 		dateInUtc := fmt.Sprintf("%d", int(responsesArray[i].Data.Matches[len(responsesArray[i].Data.Matches)-1].UtcStartSeconds))
 		// Getting the 20 games past this date.
@@ -158,13 +131,12 @@ func GetLastGamesStatsByCycles(r activision.LastGamesRequest, c int) (*activisio
 		matchesArray[i+1] = newResult.Data.Matches
 	}
 	// after we save the slices in the 'matchArray' slice we need to append each one of them
-	// into the first 20 games that we extract and that what this loop does.
-	for i, m := range matchesArray {
-		// if i == 0 thats mean that we are on the first slice that we are concat so we don't need to concat him to himself..
-		if i != 0 {
-			firstResult.Data.Matches = append(firstResult.Data.Matches, m...)
-		}
+	// into new slice, we don't append into the first response slice because it can be shared with the cache middleware.
+	matches := make([]activision.Match, 0, c*20)
+	for _, m := range matchesArray {
+		matches = append(matches, m...)
 	}
+	firstResult.Data.Matches = matches
 	// Result that contain 20*cycles games array.
 	return firstResult, nil
 }
@@ -172,215 +144,85 @@ func GetLastGamesStatsByCycles(r activision.LastGamesRequest, c int) (*activisio
 /***************************************** Function for getting the weekly and lifetime stats ********************************/
 
 // Pretty much just like the GetLastGamesStats method, except this time we are pointing to the lifetime and weekly endpoint
-// thus we need to work with different response object and url, except that the logic almost the same...
+// thus we need to work with different response object and url, except that the logic is the same...
 func GetLifetimeAndWeeklyStats(r activision.LifetimeAndWeeklyRequest) (*activision.LifetimeAndWeeklyResponse, error) {
-	// First we append the create for our request that will have the tokens from the environment variables and the user-agent header
-	// in case we don't find the environment variables we will return error.
-	headers, err := AddHeadersForActivisionRequest()
-	if err != nil {
-		log.Printf("Error: %s", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to set the headers request for last game stats request\n", StatusCode: 500}
-	}
-	// After we create the headers we creating now the url for the last games stats endpoint from the official API.
-	// In case we received invalid username / platform we will return error.
-	url, err := CreateLifetimeAndWeeklyUrl(r)
-	if err != nil {
-		return nil, err
-	}
-	// Now that we the url and the headers we can send the request, we are doing that with the "restclient" Get method which will handle the sending procedure for us,
-	// i implemented the restclient because i want to reduce the repeated code and also to have the option to mock the response result for mocking that will serve us in the tests.
-	// In case of successful request we will get the *http.Response object and err == nil, in the case of failure we will recive nil and the err.
-	response, err := restclient.Get(url, nil, *headers)
-	if err != nil {
-		log.Printf("error when trying to get last games stats from activision API: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
-	}
-	// We must to close the response.Body object when we finish to work on him, so i set defer that will execute when this method scope will close.
-	defer response.Body.Close()
-	// Attempt to read the response body into []byte object.
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		log.Printf("Failed to parse response body: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-	}
-	// Now that we have the response body in []byte format, we can try to unmarshal the response into our LifetimeAndWeeklyResponse object, because activision API doesn't use Status code to indicate
-	// if the request failed because of an invalid player details, we need to check the response status string that comes from the response body.
-	// So we have 2 cases which we will return error from this unmarshal: first one is that our LifetimeAndWeeklyResponse failed to receive the data from the response body and the second case is that
-	// the data was not found for the given username and platform...
-	var result activision.LifetimeAndWeeklyResponse
-	if err := json.Unmarshal(body, &result); err != nil || result.Status == "error" {
-		if err != nil {
-			// in this case we need to check what are the differences between the activision.LifetimeAndWeeklyResponse struct to the response that we receive from the official API for the Lifetime&Weekly endpoint.
-			log.Printf("error when trying to unmarshal life time and weekly successful response: %s\n", err.Error())
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-		} else {
-			// The case the request failed that can happen if the tokens expired or if the user gave us invalid username or platform.
-			log.Printf("Error: failed to access to activision API due invalid arguments, ask the user to verify his info and check your activision tokens!")
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: invalid player details make sure you have public profile\nif you do have public profile contact us with error code NN97\n", StatusCode: 500}
-		}
+	return Execute(lifetimeAndWeeklyEndpoint, r)
+}
 
-	}
-	// Finally that is the good case, i added manually the username and platform for the LifetimeAndWeeklyResponse result object.
-	return &result, nil
+/***************************************** Function for getting specific game stats ********************************/
+
+// GetGameStatsByID return the stats of all the players that played in the game with the given ID,
+// in case the official API does not know the game ID we will return error with 400 status code.
+func GetGameStatsByID(r activision.SpecificGameStatsRequest) (*activision.SpecificGameStatsResponse, error) {
+	return Execute(gameStatsByIDEndpoint, r)
 }
 
-// Pretty much just like the GetLastGamesStats method, except this time we are pointing to the lifetime and weekly endpoint
-// thus we need to work with different response object and url, except that the logic almost the same...
-// func GetLifetimeAndWeeklyStats(r activision.LifetimeAndWeeklyRequest) (*activision.LifetimeAndWeeklyResponse, error) {
-// 	// First we append the create for our request that will have the tokens from the environment variables and the user-agent header
-// 	// in case we don't find the environment variables we will return error.
-// 	headers, err := AddHeadersForActivisionRequest()
-// 	if err != nil {
-// 		log.Printf("Error: %s", err.Error())
-// 		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to set the headers request for last game stats request\n", StatusCode: 500}
-// 	}
-// 	// After we create the headers we creating now the url for the last games stats endpoint from the official API.
-// 	// In case we received invalid username / platform we will return error.
-// 	url, err := CreateLifetimeAndWeeklyUrl(r)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	// Now that we the url and the headers we can send the request, we are doing that with the "restclient" Get method which will handle the sending procedure for us,
-// 	// i implemented the restclient because i want to reduce the repeated code and also to have the option to mock the response result for mocking that will serve us in the tests.
-// 	// In case of successful request we will get the *http.Response object and err == nil, in the case of failure we will recive nil and the err.
-// 	response, err := restclient.Get(url, nil, *headers)
-// 	if err != nil {
-// 		log.Printf("error when trying to get last games stats from activision API: %s\n", err.Error())
-// 		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
-// 	}
-// 	// We must to close the response.Body object when we finish to work on him, so i set defer that will execute when this method scope will close.
-// 	defer response.Body.Close()
-// 	// Attempt to read the response body into []byte object.
-// 	body, err := ioutil.ReadAll(response.Body)
-// 	if err != nil {
-// 		log.Printf("Failed to parse response body: %s\n", err.Error())
-// 		return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-// 	}
-// 	// Now that we have the response body in []byte format, we can try to unmarshal the response into our LifetimeAndWeeklyResponse object, because activision API doesn't use Status code to indicate
-// 	// if the request failed because of an invalid player details, we need to check the response status string that comes from the response body.
-// 	// So we have 2 cases which we will return error from this unmarshal: first one is that our LifetimeAndWeeklyResponse failed to receive the data from the response body and the second case is that
-// 	// the data was not found for the given username and platform...
-// 	var result activision.LifetimeAndWeeklyResponse
-// 	if err := json.Unmarshal(body, &result); err != nil || result.Status == "error" {
-// 		if err != nil {
-// 			// in this case we need to check what are the differences between the activision.LifetimeAndWeeklyResponse struct to the response that we receive from the official API for the Lifetime&Weekly endpoint.
-// 			log.Printf("error when trying to unmarshal create repo successful response: %s\n", err.Error())
-// 			return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-// 		} else {
-// 			// The case the request failed that can happen if the tokens expired or if the user gave us invalid username or platform.
-// 			log.Printf("Error: failed to access to activision API due invalid arguments, ask the user to verify his info and check your activision tokens!")
-// 			return nil, &activision.ActivisionErrorResponse{Message: "Error: invalid player details make sure you have public profile\nif you do have public profile contact us with error code NN97\n", StatusCode: 500}
-// 		}
+// DefaultBulkWorkers is the number of concurrent requests of GetGamesStatsByIDs when the options does not set it.
+const DefaultBulkWorkers = 4
+
+// BulkOptions are the options of GetGamesStatsByIDs.
+type BulkOptions struct {
+	Workers int
+}
 
-// 	}
-// 	// Finally that is the good case, i added manually the username and platform for the LifetimeAndWeeklyResponse result object.
-// 	return &result, nil
-// }
+// BulkGamesResult holds the result of every game ID that succeeded in Results and the error of every game ID that failed in Errors,
+// each unique ID appear in exactly one of the maps.
+type BulkGamesResult struct {
+	Results map[string]*activision.SpecificGameStatsResponse
+	Errors  map[string]error
+}
 
-func GetGameStatsByID(r activision.SpecificGameStatsRequest) (*activision.SpecificGameStatsResponse, error) {
-	// First we append the create for our request that will have the tokens from the environment variables and the user-agent header
-	// in case we don't find the environment variables we will return error.
-	headers, err := AddHeadersForActivisionRequest()
-	if err != nil {
-		log.Printf("Error: %s", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to set the headers request for last game stats request\n", StatusCode: 500}
+// GetGamesStatsByIDs fetch the games with a pool of workers, duplicate IDs are fetched once,
+// a failure of one game does not stop the others so the caller receive everything that succeeded.
+func GetGamesStatsByIDs(ids []string, opts BulkOptions) BulkGamesResult {
+	result := BulkGamesResult{
+		Results: make(map[string]*activision.SpecificGameStatsResponse),
+		Errors:  make(map[string]error),
+	}
+	seen := make(map[string]bool)
+	var unique []string
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
 	}
-	// After we create the headers we creating now the url for the last games stats endpoint from the official API.
-	// In case we received invalid username / platform we will return error.
-	url, err := CreateGetSpecificGameUrl(r)
-	if err != nil {
-		return nil, err
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultBulkWorkers
 	}
-	// Now that we the url and the headers we can send the request, we are doing that with the "restclient" Get method which will handle the sending procedure for us,
-	// i implemented the restclient because i want to reduce the repeated code and also to have the option to mock the response result for mocking that will serve us in the tests.
-	// In case of successful request we will get the *http.Response object and err == nil, in the case of failure we will recive nil and the err.
-	response, err := restclient.Get(url, nil, *headers)
-	if err != nil {
-		log.Printf("error when trying to get game stats by ID from activision API: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
+	if workers > len(unique) {
+		workers = len(unique)
 	}
-	// We must to close the response.Body object when we finish to work on him, so i set defer that will execute when this method scope will close.
-	defer response.Body.Close()
-	// Attempt to read the response body into []byte object.
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		log.Printf("Failed to parse response body: %s\n", err.Error())
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
+
+	type gameResult struct {
+		id       string
+		response *activision.SpecificGameStatsResponse
+		err      error
 	}
-	// Now that we have the response body in []byte format, we can try to unmarshal the response into our LifetimeAndWeeklyResponse object, because activision API doesn't use Status code to indicate
-	// if the request failed because of an invalid player details, we need to check the response status string that comes from the response body.
-	// So we have 2 cases which we will return error from this unmarshal: first one is that our LifetimeAndWeeklyResponse failed to receive the data from the response body and the second case is that
-	// the data was not found for the given username and platform...
-	var result activision.SpecificGameStatsResponse
-	if err := json.Unmarshal(body, &result); err != nil || result.Status == "error" {
-		if err != nil {
-			// in this case we need to check what are the differences between the activision.LifetimeAndWeeklyResponse struct to the response that we receive from the official API for the Lifetime&Weekly endpoint.
-			log.Printf("error when trying to unmarshal life time and weekly successful response: %s\n", err.Error())
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
+	jobs := make(chan string)
+	results := make(chan gameResult)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for id := range jobs {
+				response, err := GetGameStatsByID(activision.SpecificGameStatsRequest{GameID: id})
+				results <- gameResult{id: id, response: response, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, id := range unique {
+			jobs <- id
+		}
+		close(jobs)
+	}()
+	for range unique {
+		r := <-results
+		if r.err != nil {
+			result.Errors[r.id] = r.err
 		} else {
-			// The case the request failed that can happen if the tokens expired or if the user gave us invalid username or platform.
-			log.Printf("Error: failed to access to activision API due invalid arguments, ask the user to verify his info and check your activision tokens!")
-			return nil, &activision.ActivisionErrorResponse{Message: "Error: invalid player details make sure you have public profile\nif you do have public profile contact us with error code NN97\n", StatusCode: 500}
+			result.Results[r.id] = r.response
 		}
-
 	}
-	if len(result.Data.AllPlayers) == 0 {
-		log.Printf("error when try to get game data by ID, that because we received invalid game ID\n")
-		return nil, &activision.ActivisionErrorResponse{Message: "Error: invalid Game ID received.\n", StatusCode: 400}
-	}
-	// Finally that is the good case, i added manually the username and platform for the LifetimeAndWeeklyResponse result object.
-	return &result, nil
+	return result
 }
-
-// Pretty much just like the GetLastGamesStats method, except this time we are pointing to the lifetime and weekly endpoint
-// thus we need to work with different response object and url, except that the logic almost the same...
-// func GetLifetimeAndWeeklyStats(r activision.LifetimeAndWeeklyRequest) (*activision.LifetimeAndWeeklyResponse, error) {
-// 	// First we append the create for our request that will have the tokens from the environment variables and the user-agent header
-// 	// in case we don't find the environment variables we will return error.
-// 	headers, err := AddHeadersForActivisionRequest()
-// 	if err != nil {
-// 		log.Printf("Error: %s", err.Error())
-// 		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to set the headers request for last game stats request\n", StatusCode: 500}
-// 	}
-// 	// After we create the headers we creating now the url for the last games stats endpoint from the official API.
-// 	// In case we received invalid username / platform we will return error.
-// 	url, err := CreateLifetimeAndWeeklyUrl(r)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	// Now that we the url and the headers we can send the request, we are doing that with the "restclient" Get method which will handle the sending procedure for us,
-// 	// i implemented the restclient because i want to reduce the repeated code and also to have the option to mock the response result for mocking that will serve us in the tests.
-// 	// In case of successful request we will get the *http.Response object and err == nil, in the case of failure we will recive nil and the err.
-// 	response, err := restclient.Get(url, nil, *headers)
-// 	if err != nil {
-// 		log.Printf("error when trying to get last games stats from activision API: %s\n", err.Error())
-// 		return nil, &activision.ActivisionErrorResponse{Message: "Error: Failed to get data from activision API\n", StatusCode: 500}
-// 	}
-// 	// We must to close the response.Body object when we finish to work on him, so i set defer that will execute when this method scope will close.
-// 	defer response.Body.Close()
-// 	// Attempt to read the response body into []byte object.
-// 	body, err := ioutil.ReadAll(response.Body)
-// 	if err != nil {
-// 		log.Printf("Failed to parse response body: %s\n", err.Error())
-// 		return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-// 	}
-// 	// Now that we have the response body in []byte format, we can try to unmarshal the response into our LifetimeAndWeeklyResponse object, because activision API doesn't use Status code to indicate
-// 	// if the request failed because of an invalid player details, we need to check the response status string that comes from the response body.
-// 	// So we have 2 cases which we will return error from this unmarshal: first one is that our LifetimeAndWeeklyResponse failed to receive the data from the response body and the second case is that
-// 	// the data was not found for the given username and platform...
-// 	var result activision.LifetimeAndWeeklyResponse
-// 	if err := json.Unmarshal(body, &result); err != nil || result.Status == "error" {
-// 		if err != nil {
-// 			// in this case we need to check what are the differences between the activision.LifetimeAndWeeklyResponse struct to the response that we receive from the official API for the Lifetime&Weekly endpoint.
-// 			log.Printf("error when trying to unmarshal create repo successful response: %s\n", err.Error())
-// 			return nil, &activision.ActivisionErrorResponse{Message: "Error: Invalid response body\n", StatusCode: 500}
-// 		} else {
-// 			// The case the request failed that can happen if the tokens expired or if the user gave us invalid username or platform.
-// 			log.Printf("Error: failed to access to activision API due invalid arguments, ask the user to verify his info and check your activision tokens!")
-// 			return nil, &activision.ActivisionErrorResponse{Message: "Error: invalid player details make sure you have public profile\nif you do have public profile contact us with error code NN97\n", StatusCode: 500}
-// 		}
-
-// 	}
-// 	// Finally that is the good case, i added manually the username and platform for the LifetimeAndWeeklyResponse result object.
-// 	return &result, nil
-// }
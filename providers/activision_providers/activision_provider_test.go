@@ -0,0 +1,142 @@
+package activision_providers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/config"
+)
+
+// stubRestGet replace restGet until the end of the test with a function that answer every full match url from the
+// game ID in the url: IDs in failed get an empty players array (invalid game ID), the others get a single player.
+// It return the number of requests of every game ID and the maximum number of requests that were sent at the same time.
+func stubRestGet(t *testing.T, failed map[string]bool, delay time.Duration) (func(id string) int, func() int64) {
+	t.Helper()
+	var mutex sync.Mutex
+	requests := make(map[string]int)
+	var inFlight, maxInFlight atomic.Int64
+
+	oldGet, oldMode := restGet, currentSchemaMode()
+	atkn, cookie, expiry := config.ATKN, config.ACT_SSO_COOKIE, config.ACT_SSO_COOKIE_EXPIRY
+	config.ATKN, config.ACT_SSO_COOKIE, config.ACT_SSO_COOKIE_EXPIRY = "atkn", "cookie", "1"
+	SetSchemaMode(SchemaOff)
+	t.Cleanup(func() {
+		restGet = oldGet
+		SetSchemaMode(oldMode)
+		config.ATKN, config.ACT_SSO_COOKIE, config.ACT_SSO_COOKIE_EXPIRY = atkn, cookie, expiry
+	})
+	restGet = func(url string, body interface{}, headers http.Header) (*http.Response, error) {
+		id := path.Base(path.Dir(url))
+		mutex.Lock()
+		requests[id]++
+		mutex.Unlock()
+
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if current <= m || maxInFlight.CompareAndSwap(m, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+
+		players := fmt.Sprintf(`[{"matchID":%q}]`, id)
+		if failed[id] {
+			players = "[]"
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"status":"success","data":{"allPlayers":` + players + `}}`)),
+		}, nil
+	}
+	count := func(id string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return requests[id]
+	}
+	return count, maxInFlight.Load
+}
+
+func TestGetGamesStatsByIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []string
+		failed   map[string]bool
+		workers  int
+		requests map[string]int
+		results  int
+		errors   int
+	}{
+		{name: "no IDs", workers: 2},
+		{name: "duplicate IDs are fetched once", ids: []string{"1", "2", "1", "1", "2"}, requests: map[string]int{"1": 1, "2": 1}, results: 2},
+		{name: "partial success", ids: []string{"1", "2", "3", "4"}, failed: map[string]bool{"2": true, "4": true},
+			requests: map[string]int{"1": 1, "2": 1, "3": 1, "4": 1}, results: 2, errors: 2},
+		{name: "duplicate failed ID", ids: []string{"5", "5"}, failed: map[string]bool{"5": true}, requests: map[string]int{"5": 1}, errors: 1},
+		{name: "invalid game ID is not sent", ids: []string{"1", "abc"}, requests: map[string]int{"1": 1, "abc": 0}, results: 1, errors: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, _ := stubRestGet(t, tt.failed, 0)
+			result := GetGamesStatsByIDs(tt.ids, BulkOptions{Workers: tt.workers})
+			if len(result.Results) != tt.results || len(result.Errors) != tt.errors {
+				t.Fatalf("expected %d results and %d errors, got %d results and %d errors (%v)", tt.results, tt.errors,
+					len(result.Results), len(result.Errors), result.Errors)
+			}
+			for id, response := range result.Results {
+				if _, failed := result.Errors[id]; failed {
+					t.Errorf("game %s is in both the results and the errors", id)
+				}
+				if response.Data.AllPlayers[0].MatchID != id {
+					t.Errorf("game %s got the response of game %s", id, response.Data.AllPlayers[0].MatchID)
+				}
+			}
+			for id := range tt.failed {
+				if result.Errors[id] == nil {
+					t.Errorf("expected error for game %s", id)
+				}
+			}
+			for id, expected := range tt.requests {
+				if got := count(id); got != expected {
+					t.Errorf("expected %d requests for game %s, got %d", expected, id, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetGamesStatsByIDsWorkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     int
+		workers int
+		want    int64
+	}{
+		{name: "bounded by the workers", ids: 12, workers: 3, want: 3},
+		{name: "default workers", ids: 12, want: DefaultBulkWorkers},
+		{name: "less IDs than workers", ids: 2, workers: 8, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, maxInFlight := stubRestGet(t, nil, 20*time.Millisecond)
+			ids := make([]string, tt.ids)
+			for i := range ids {
+				ids[i] = fmt.Sprint(i + 1)
+			}
+			result := GetGamesStatsByIDs(ids, BulkOptions{Workers: tt.workers})
+			if len(result.Results) != tt.ids {
+				t.Fatalf("expected %d results, got %d (%v)", tt.ids, len(result.Results), result.Errors)
+			}
+			if got := maxInFlight(); got != tt.want {
+				t.Errorf("expected %d concurrent requests, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -5,7 +5,7 @@ package activision_providers
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -27,7 +27,8 @@ func AddHeadersForActivisionRequest() (*http.Header, error) {
 	headers.Add("user-agent", "golangNiv")
 	cookieHeader, err := GetAuthorizationHeader()
 	if err != nil {
-		log.Printf("Error when try to get the tokens value from the environment variables")
+		l, _ := currentLogger()
+		l.Error("failed to get the tokens value from the environment variables", slog.String("error", err.Error()))
 		return nil, &activision.ActivisionErrorResponse{Message: "Error: Environment variables not set", StatusCode: 500}
 	}
 
@@ -49,35 +50,21 @@ func GetAuthorizationHeader() (string, error) {
 /***************************************** Help functions for ActivisionRequest objects **********************************************/
 
 // validatePlatform validates the platform which we received from the ActivisionRequest, in case of invalid platform name we will return an error, and in case of valid platform name we will return err==nil
+// the platform aliases (PSN, playstation, xbox...) are accepted, see NormalizePlatform.
 func ValidatePlatform(r activision.ActivisionRequest) error {
-	platforms := []string{"psn", "xbl", "battle", "uno"}
-	for _, platform := range platforms {
-		if platform == r.GetPlatform() {
-			return nil
-		}
-	}
-	return &activision.ActivisionErrorResponse{Message: "Error: invalid platform received for last games stats", StatusCode: 400}
+	_, err := NormalizePlatform(r.GetPlatform())
+	return err
 }
 
-// fixUsername function will made a url encoding for player from "battle" or "uno" which their username contain '#' that need to convert into %23 encoding,
-// if player is not from those platform we just return his name as we received, in case of an error the function return empty string and the error.
+// fixUsername function will validate the username according to the player platform and will return him url encoded,
+// for example the username: "nivGolanigo#1234" will converted into "nivGolanigo%231234", in case of an error the function return empty string and the error.
 // in successful case err == nil
 func FixUsername(r activision.ActivisionRequest) (string, error) {
-	// for example the username: "nivGolanigo#1234" will conveted into "nivGolanigo%231234" if his platform is "battle" or "uno".
-	splittedUsername := strings.Split(r.GetUsername(), "#")
-
-	if r.GetPlatform() == "battle" || r.GetPlatform() == "uno" {
-		if len(splittedUsername) != 2 {
-			return "", &activision.ActivisionErrorResponse{Message: "Error: invalid username received for last games stats request\n", StatusCode: 400}
-		}
-		return splittedUsername[0] + "%23" + splittedUsername[1], nil
-	}
-
-	// a user from the other platforms should not contain the '#' character in his name!
-	if len(splittedUsername) != 1 {
-		return "", &activision.ActivisionErrorResponse{Message: "Error: invalid username received for last games stats request\n", StatusCode: 400}
+	player, err := NormalizeRequest(r)
+	if err != nil {
+		return "", err
 	}
-	return r.GetUsername(), nil
+	return player.EscapedUsername, nil
 }
 
 /***************************************** Help functions for specific ActivisionRequest objects **********************************************/
@@ -85,65 +72,42 @@ func FixUsername(r activision.ActivisionRequest) (string, error) {
 // createLastGameStatsUrl will try to fill the urlGetLastGameStats url wildcard with the username and platform that received from the ActivisionRequest.
 // in case of an error we will return empty string and the error, and in case of successful fill we return the fixed url and err==nil.
 func CreateLastGamesStatsUrl(r activision.ActivisionRequest) (string, error) {
-	if len(r.GetUsername()) == 0 || len(r.GetPlatform()) == 0 {
-		return "", &activision.ActivisionErrorResponse{Message: "Error: missing argument for receive for the last games stats search\n", StatusCode: 400}
-	}
-
-	if err := ValidatePlatform(r); err != nil {
-		return "", err
-	}
-
-	fixedUsername, err := FixUsername(r)
+	player, err := NormalizeRequest(r)
 	if err != nil {
 		return "", err
 	}
-
-	return fmt.Sprintf(urlGetLastGameStats, r.GetPlatform(), fixedUsername), nil
+	return fmt.Sprintf(urlGetLastGameStats, player.Platform, player.EscapedUsername), nil
 }
 
 // createLastGameStatsUrl will try to fill the urlGetLastGameStats url wildcard with the username and platform that received from the ActivisionRequest.
 // in case of an error we will return empty string and the error, and in case of successful fill we return the fixed url and err==nil.
 func CreateLastGamesStatsByDateUrl(r activision.ActivisionRequest, d string) (string, error) {
-	if len(r.GetUsername()) == 0 || len(r.GetPlatform()) == 0 || len(d) == 0 {
-		return "", &activision.ActivisionErrorResponse{Message: "Error: missing argument for receive for the last games stats by date search\n", StatusCode: 400}
-	}
-
-	if err := ValidatePlatform(r); err != nil {
-		return "", err
-	}
-
-	fixedUsername, err := FixUsername(r)
+	player, err := NormalizeRequest(r)
 	if err != nil {
 		return "", err
 	}
-
-	return fmt.Sprintf(urlGetLastGameStatsByDate, r.GetPlatform(), fixedUsername, d), nil
+	// The date is utc time in milliseconds so it must contain only digits.
+	if len(d) == 0 || strings.Trim(d, "0123456789") != "" {
+		return "", activision.NewValidationError("date", d, "date must be utc time in milliseconds")
+	}
+	return fmt.Sprintf(urlGetLastGameStatsByDate, player.Platform, player.EscapedUsername, d), nil
 }
 
 // CreateLifetimeAndWeeklyUrl will try to fill the urlGetLifetimeAndWeekly url wildcard with the username and platform that received from the ActivisionRequest.
 // in case of an error we will return empty string and the error, and in case of successful fill we return the fixed url and err==nil.
 func CreateLifetimeAndWeeklyUrl(r activision.ActivisionRequest) (string, error) {
-	if len(r.GetUsername()) == 0 || len(r.GetPlatform()) == 0 {
-		return "", &activision.ActivisionErrorResponse{Message: "Error: missing argument for receive for the lifetime and weekly stats search\n", StatusCode: 400}
-	}
-
-	if err := ValidatePlatform(r); err != nil {
-		return "", err
-	}
-
-	fixedUsername, err := FixUsername(r)
+	player, err := NormalizeRequest(r)
 	if err != nil {
 		return "", err
 	}
-
-	return fmt.Sprintf(urlGetLifetimeAndWeekly, r.GetPlatform(), fixedUsername), nil
+	return fmt.Sprintf(urlGetLifetimeAndWeekly, player.Platform, player.EscapedUsername), nil
 }
 
 /************************************************************************************************/
 
 func CreateGetSpecificGameUrl(r activision.SpecificGameStatsRequest) (string, error) {
-	if len(r.GameID) == 0 {
-		return "", &activision.ActivisionErrorResponse{Message: "Error: missing game ID for 'CreateGetSpecificGameUrl' function.\n", StatusCode: 400}
+	if err := ValidateGameID(r.GameID); err != nil {
+		return "", err
 	}
 	return fmt.Sprintf(urlGetSpecificGameStatsByID, r.GameID), nil
 }
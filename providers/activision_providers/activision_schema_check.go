@@ -0,0 +1,117 @@
+// This file connect the schema drift detection from the activision domain into the requests pipeline.
+
+package activision_providers
+
+import (
+	"log/slog"
+	"reflect"
+	"sync"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// SchemaMode decide what we do when the structure of the official API response is different from what we expect.
+type SchemaMode int
+
+const (
+	// SchemaOff skip all the schema checks.
+	SchemaOff SchemaMode = iota
+	// SchemaWarn log the drift as warning and return the response as usual, this is the default mode.
+	// Only a sample of the responses is compared with the fixtures, see SetSchemaSampleRate.
+	SchemaWarn
+	// SchemaStrict return SchemaDriftError when drift found, and also decode the response with DisallowUnknownFields
+	// in order to report the first field that our domain objects does not model.
+	SchemaStrict
+)
+
+// DefaultSchemaSampleRate is the default number of responses of every endpoint that one of them is compared with the fixture
+// in SchemaWarn mode. The comparison keeps the whole payload in memory and decodes it again, so doing it for every response
+// would cancel the streaming decoding.
+const DefaultSchemaSampleRate = 100
+
+var (
+	schemaModeMutex  sync.RWMutex
+	schemaMode       = SchemaWarn
+	schemaSampleRate = DefaultSchemaSampleRate
+	schemaSamples    = make(map[string]int) // the number of responses of every endpoint since the last comparison
+)
+
+// SetSchemaMode change the schema checks mode of all the endpoints.
+func SetSchemaMode(mode SchemaMode) {
+	schemaModeMutex.Lock()
+	defer schemaModeMutex.Unlock()
+	schemaMode = mode
+}
+
+// SetSchemaSampleRate change the number of responses of every endpoint that one of them is compared with the fixture in
+// SchemaWarn mode, rate 1 compare every response and rate <= 0 reset it to the default.
+func SetSchemaSampleRate(rate int) {
+	schemaModeMutex.Lock()
+	defer schemaModeMutex.Unlock()
+	if rate <= 0 {
+		rate = DefaultSchemaSampleRate
+	}
+	schemaSampleRate = rate
+	schemaSamples = make(map[string]int)
+}
+
+func currentSchemaMode() SchemaMode {
+	schemaModeMutex.RLock()
+	defer schemaModeMutex.RUnlock()
+	return schemaMode
+}
+
+// schemaNeedsPayload return true when the payload of the call must be kept in order to compare it with the fixture,
+// SchemaStrict compare every response and SchemaWarn compare the first response of every endpoint and then one of every
+// schemaSampleRate responses. The required fields are checked on every response because they don't need the payload.
+func schemaNeedsPayload(c *Call) bool {
+	schemaModeMutex.Lock()
+	defer schemaModeMutex.Unlock()
+	switch {
+	case schemaMode == SchemaStrict:
+		return true
+	case schemaMode == SchemaOff || c.Fixture == "":
+		return false
+	}
+	sample := schemaSamples[c.Endpoint]%schemaSampleRate == 0
+	schemaSamples[c.Endpoint]++
+	return sample
+}
+
+// checkSchema run the required fields validation of the result and compare the payload with the endpoint fixture.
+func checkSchema(c *Call, payload []byte) error {
+	mode := currentSchemaMode()
+	if mode == SchemaOff {
+		return nil
+	}
+	l := callLogger(c)
+	drift := activision.SchemaDrift{Endpoint: c.Endpoint}
+	if validator, ok := c.Result.(activision.RequiredFieldsValidator); ok {
+		drift.MissingRequired = validator.MissingRequiredFields()
+	}
+	if c.Fixture != "" && payload != nil {
+		newFields, removedFields, err := activision.CompareWithFixture(c.Fixture, payload)
+		if err != nil {
+			l.Error("failed to compare the response with the fixture", slog.String("fixture", c.Fixture), slog.String("error", err.Error()))
+		}
+		drift.NewFields = newFields
+		drift.RemovedFields = removedFields
+	}
+	if mode == SchemaStrict && payload != nil {
+		strictResult := reflect.New(reflect.TypeOf(c.Result).Elem()).Interface()
+		drift.UnmodeledField = activision.UnknownFieldName(activision.DecodeStrict(payload, strictResult))
+		if drift.UnmodeledField != "" {
+			l.Info("strict decoding found field that is not modeled", slog.String("field", drift.UnmodeledField))
+		}
+	}
+
+	if !drift.HasDrift() {
+		return nil
+	}
+	l.Warn("schema drift detected",
+		slog.Any("missing_required", drift.MissingRequired), slog.Any("new_fields", drift.NewFields), slog.Any("removed_fields", drift.RemovedFields))
+	if mode == SchemaStrict {
+		return &activision.SchemaDriftError{StatusCode: 502, Drift: drift}
+	}
+	return nil
+}
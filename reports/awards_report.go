@@ -0,0 +1,62 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// RenderMatchAwards write the awards that the player earned in a single match in the order they were earned.
+func RenderMatchAwards(w io.Writer, username string, awards activision.Awards) error {
+	fmt.Fprintf(w, "Awards of %s (%d)\n", username, len(awards))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, a := range analytics.MatchAwards(awards) {
+		fmt.Fprintf(tw, "  %s\t%s\n", a.EarnedAt.Truncate(time.Second), a.Name)
+	}
+	return tw.Flush()
+}
+
+// RenderAwards write the awards summary, every award with the number of matches it was earned in.
+func RenderAwards(w io.Writer, s analytics.AwardsSummary) error {
+	fmt.Fprintf(w, "Awards: %d in %d matches\n", s.Total, s.Matches)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Award\tMatches\t%% of matches\n")
+	for _, a := range s.Awards {
+		fmt.Fprintf(tw, "  %s\t%d\t%.1f\n", a.Name, a.Matches, a.Percent)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(s.Rarest) > 0 {
+		fmt.Fprintf(w, "  Rarest: %s\n", awardCounts(s.Rarest))
+	}
+	return nil
+}
+
+// RenderPlayersAwards write the awards summary of every player.
+func RenderPlayersAwards(w io.Writer, players []analytics.PlayerAwards) error {
+	for i, p := range players {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, p.Player)
+		if err := RenderAwards(w, p.Awards); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// awardCounts format the awards as one line, for example "Killing Spree x4, Squad Wipe x3".
+func awardCounts(awards []analytics.AwardCount) string {
+	parts := make([]string, 0, len(awards))
+	for _, a := range awards {
+		parts = append(parts, fmt.Sprintf("%s x%d", a.Name, a.Matches))
+	}
+	return strings.Join(parts, ", ")
+}
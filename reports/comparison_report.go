@@ -0,0 +1,46 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+)
+
+// RenderComparison write the players comparison as three tables (lifetime, weekly and last games),
+// the winner of each metric is marked with '*' near his value and players without data for the metric have '-'.
+func RenderComparison(w io.Writer, c analytics.PlayersComparison) error {
+	sections := []struct {
+		title   string
+		metrics []analytics.MetricComparison
+	}{
+		{"Lifetime", c.Lifetime},
+		{"Weekly", c.Weekly},
+		{fmt.Sprintf("Last %d games", c.Games), c.LastGames},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(w, "%s\n", section.title)
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "  Metric\t%s\tDiff\n", strings.Join(c.Players, "\t"))
+		for _, m := range section.metrics {
+			values := make([]string, len(m.Values))
+			for i, v := range m.Values {
+				values[i] = fmt.Sprintf("%.2f", v)
+				if m.NoData != nil && m.NoData[i] {
+					values[i] = "-"
+				}
+				if i == m.WinnerIndex {
+					values[i] += "*"
+				}
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%.1f%%\n", m.Metric, strings.Join(values, "\t"), m.PercentDifference)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
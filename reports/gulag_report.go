@@ -0,0 +1,57 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+)
+
+const dayFormat = "2006-01-02"
+
+// RenderGulag write the gulag record of the player split by mode and by day, and the placements by the gulag outcome.
+func RenderGulag(w io.Writer, r analytics.GulagReport) error {
+	fmt.Fprintf(w, "Gulag: %s (%d matches)\n", r.Player, r.Matches)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  \tVisits\tWins\tLosses\tWin %%\tReliability %%\n")
+	writeGulagRow(tw, "Overall", r.Overall)
+	for _, m := range r.ByMode {
+		writeGulagRow(tw, m.Mode, m.GulagStats)
+	}
+	for _, d := range r.ByDay {
+		writeGulagRow(tw, d.Day.Format(dayFormat), d.GulagStats)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "  Avg placement after win %.1f, after loss %.1f, without gulag %.1f (correlation %.2f)\n",
+		r.Placement.AfterWin, r.Placement.AfterLoss, r.Placement.NoGulag, r.Placement.Correlation)
+	return nil
+}
+
+// RenderSquadGulag write the squad record and the members ranked by their gulag reliability.
+func RenderSquadGulag(w io.Writer, r analytics.SquadGulagReport) error {
+	fmt.Fprintln(w, "Squad gulag reliability")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  \tVisits\tWins\tLosses\tWin %%\tReliability %%\n")
+	writeGulagRow(tw, "Squad", r.Overall)
+	for _, p := range r.Players {
+		writeGulagRow(tw, p.Player, p.Overall)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	for _, p := range r.Players {
+		if err := RenderGulag(w, p); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func writeGulagRow(w io.Writer, title string, s analytics.GulagStats) {
+	fmt.Fprintf(w, "  %s\t%d\t%d\t%d\t%.1f\t%.1f\n", title, s.Visits, s.Wins, s.Losses, s.WinRate, s.Reliability)
+}
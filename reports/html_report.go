@@ -0,0 +1,141 @@
+// This file renders the matches report of a player or a squad as a single html page, the charts of the page are
+// drawn by svg_charts.go.
+
+package reports
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// htmlReportView is the data of the html template, the charts are ready SVG.
+type htmlReportView struct {
+	Title          string
+	Generated      string
+	Report         analytics.MatchesReport
+	KdChart        template.HTML
+	DamageChart    template.HTML
+	PlacementChart template.HTML
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"mapName": activision.MapName,
+	"date": func(seconds float64) string {
+		return time.Unix(int64(seconds), 0).UTC().Format("2006-01-02 15:04")
+	},
+}).Parse(htmlReportSource))
+
+// RenderHTML write the report as a single html file with inline css and SVG charts, the file does not load anything
+// from the internet so it can be sent as an attachment.
+func RenderHTML(w io.Writer, title string, report analytics.MatchesReport, generated time.Time) error {
+	view := htmlReportView{
+		Title:     title,
+		Generated: generated.UTC().Format("2006-01-02 15:04 MST"),
+		Report:    report,
+	}
+	var kd, damage []chartSeries
+	for _, trend := range report.Trends {
+		kdSeries := chartSeries{Name: trend.Player}
+		damageSeries := chartSeries{Name: trend.Player}
+		for _, p := range trend.Points {
+			kdSeries.Points = append(kdSeries.Points, chartPoint{X: p.UtcStartSeconds, Y: p.Averages.KdRatio})
+			damageSeries.Points = append(damageSeries.Points, chartPoint{X: p.UtcStartSeconds, Y: p.Averages.DamageDone})
+		}
+		kd = append(kd, kdSeries)
+		damage = append(damage, damageSeries)
+	}
+	view.KdChart = lineChart(kd, "%.2f")
+	view.DamageChart = lineChart(damage, "%.0f")
+	labels := make([]string, len(report.Placements))
+	values := make([]float64, len(report.Placements))
+	for i, bucket := range report.Placements {
+		labels[i], values[i] = bucket.Label, float64(bucket.Games)
+	}
+	view.PlacementChart = barChart(labels, values)
+	return htmlReportTemplate.Execute(w, view)
+}
+
+const htmlReportSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+	body { font-family: -apple-system, "Segoe UI", Roboto, Arial, sans-serif; background: #14161a; color: #e8e8e8; margin: 0; padding: 24px; }
+	main { max-width: 960px; margin: 0 auto; }
+	h1 { margin: 0 0 4px; }
+	h2 { margin: 32px 0 12px; font-size: 18px; }
+	.muted, .empty { color: #8d99ae; }
+	.cards { display: flex; flex-wrap: wrap; gap: 12px; margin-top: 20px; }
+	.card { background: #1f2329; border-radius: 8px; padding: 12px 16px; min-width: 110px; }
+	.card b { display: block; font-size: 22px; }
+	.chart { width: 100%; height: auto; background: #1f2329; border-radius: 8px; }
+	.chart .grid { stroke: #2f353d; }
+	.chart .axis { fill: #8d99ae; font-size: 11px; }
+	.chart .value { fill: #e8e8e8; font-size: 11px; }
+	.legend { margin-top: 6px; font-size: 13px; }
+	.legend span { margin-right: 16px; }
+	.legend i { display: inline-block; width: 10px; height: 10px; border-radius: 2px; margin-right: 6px; }
+	table { width: 100%; border-collapse: collapse; background: #1f2329; border-radius: 8px; overflow: hidden; }
+	th, td { padding: 8px 10px; text-align: left; border-bottom: 1px solid #2f353d; font-size: 14px; }
+	th { color: #8d99ae; font-weight: normal; }
+	td.n, th.n { text-align: right; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+<div class="muted">{{range $i, $p := .Report.Players}}{{if $i}}, {{end}}{{$p}}{{end}} &middot; generated {{.Generated}}</div>
+
+{{with .Report}}
+<div class="cards">
+	<div class="card">Games<b>{{.Games}}</b></div>
+	<div class="card">Wins<b>{{.Wins}}</b></div>
+	<div class="card">KD<b>{{printf "%.2f" .Kd}}</b></div>
+	<div class="card">Kills<b>{{printf "%.0f" .Kills}}</b></div>
+	<div class="card">Damage<b>{{printf "%.0f" .DamageDone}}</b></div>
+	<div class="card">Avg placement<b>{{printf "%.1f" .AveragePlacement}}</b></div>
+</div>
+{{end}}
+
+<h2>KD trend <span class="muted">(rolling {{.Report.TrendWindow}} games)</span></h2>
+{{.KdChart}}
+
+<h2>Damage trend <span class="muted">(rolling {{.Report.TrendWindow}} games average)</span></h2>
+{{.DamageChart}}
+
+<h2>Placements</h2>
+{{.PlacementChart}}
+
+<h2>Modes</h2>
+<table>
+	<tr><th>Mode</th><th class="n">Games</th><th class="n">Wins</th><th class="n">Win %</th><th class="n">Avg placement</th><th class="n">KD</th></tr>
+	{{range .Report.Modes}}
+	<tr><td>{{.Mode}}</td><td class="n">{{.Games}}</td><td class="n">{{.Wins}}</td><td class="n">{{printf "%.1f" .WinPercent}}</td><td class="n">{{printf "%.1f" .AveragePlacement}}</td><td class="n">{{printf "%.2f" .Kd}}</td></tr>
+	{{end}}
+</table>
+
+<h2>Maps</h2>
+<table>
+	<tr><th>Map</th><th class="n">Games</th><th class="n">Wins</th><th class="n">Win %</th><th class="n">Avg placement</th><th class="n">KD</th></tr>
+	{{range .Report.Maps}}
+	<tr><td>{{.Name}}</td><td class="n">{{.Games}}</td><td class="n">{{.Wins}}</td><td class="n">{{printf "%.1f" .WinPercent}}</td><td class="n">{{printf "%.1f" .AveragePlacement}}</td><td class="n">{{printf "%.2f" .Kd}}</td></tr>
+	{{end}}
+</table>
+
+<h2>Top games</h2>
+<table>
+	<tr><th>Date</th><th>Player</th><th>Mode</th><th>Map</th><th class="n">Placement</th><th class="n">Kills</th><th class="n">Deaths</th><th class="n">Damage</th></tr>
+	{{range .Report.TopGames}}
+	<tr><td>{{date .Match.UtcStartSeconds}}</td><td>{{.Player}}</td><td>{{.Match.Mode}}</td><td>{{mapName .Match.Map}}</td><td class="n">{{printf "%.0f" .Match.PlayerStats.TeamPlacement}}</td><td class="n">{{printf "%.0f" .Match.PlayerStats.Kills}}</td><td class="n">{{printf "%.0f" .Match.PlayerStats.Deaths}}</td><td class="n">{{printf "%.0f" .Match.PlayerStats.DamageDone}}</td></tr>
+	{{end}}
+</table>
+</main>
+</body>
+</html>
+`
@@ -0,0 +1,39 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+)
+
+// RenderLeaderboard write the leaderboard as a ranked table.
+func RenderLeaderboard(w io.Writer, board analytics.Leaderboard) error {
+	title := fmt.Sprintf("Leaderboard: %s (%s)", board.Metric, board.Window)
+	if !board.Since.IsZero() {
+		title += " since " + board.Since.Format(reportTimeFormat)
+	}
+	fmt.Fprintln(w, title)
+	if len(board.Entries) == 0 {
+		fmt.Fprintln(w, "  no games in this window")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Rank\tPlayer\tValue\tGames\n")
+	fallback := false
+	for _, e := range board.Entries {
+		mark := ""
+		if e.LifetimeFailed {
+			mark, fallback = " *", true
+		}
+		fmt.Fprintf(tw, "  %d\t%s%s\t%.2f\t%d\n", e.Rank, e.Player, mark, e.Value, e.Games)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if fallback {
+		fmt.Fprintln(w, "  * failed to get the lifetime stats, ranked by the saved matches")
+	}
+	return nil
+}
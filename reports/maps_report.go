@@ -0,0 +1,20 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+)
+
+// RenderMaps write the performance on every map as a table.
+func RenderMaps(w io.Writer, maps []analytics.MapStats) error {
+	fmt.Fprintln(w, "Maps")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Map\tGames\tWins\tWin %%\tAvg placement\tKD\n")
+	for _, m := range maps {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%.1f\t%.1f\t%.2f\n", m.Name, m.Games, m.Wins, m.WinPercent, m.AveragePlacement, m.Kd)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,42 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// RenderMatch write the lobby of the match as a table of the teams by their placement, every team with its members
+// and their kills.
+func RenderMatch(w io.Writer, matchID string, r activision.SpecificGameStatsResponse) error {
+	if len(r.Data.AllPlayers) == 0 {
+		fmt.Fprintf(w, "Match %s\n  no players\n", matchID)
+		return nil
+	}
+	first := r.Data.AllPlayers[0]
+	fmt.Fprintf(w, "Match %s: %s on %s, %s, %.0f players\n", matchID, first.Mode, activision.MapName(first.Map),
+		time.Unix(int64(first.UtcStartSeconds), 0).UTC().Format(reportTimeFormat), first.PlayerCount)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Place\tKills\tDeaths\tDamage\tSurvived\tPlayers\n")
+	for _, t := range r.Teams() {
+		members := make([]string, 0, len(t.Members))
+		for _, p := range t.Members {
+			members = append(members, fmt.Sprintf("%s (%.0f)", p.Player.Username, p.PlayerStats.Kills))
+		}
+		fmt.Fprintf(tw, "  %s\t%.0f\t%.0f\t%.0f\t%s\t%s\n", placement(t.Placement), t.Kills, t.Deaths, t.DamageDone,
+			(time.Duration(t.SurvivalTime) * time.Second).String(), strings.Join(members, ", "))
+	}
+	return tw.Flush()
+}
+
+// placement return the placement of the team, "-" when the API has no placement for it.
+func placement(p float64) string {
+	if p <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", p)
+}
@@ -0,0 +1,36 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+)
+
+// RenderObjectives write the objectives of every squad member and the leader of each objective.
+func RenderObjectives(w io.Writer, r analytics.SquadObjectivesReport) error {
+	fmt.Fprintf(w, "Squad objectives (%d matches)\n", r.Matches)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Player\tMatches\tCaches\tBuys\tRevives\tTablets\tContracts\tTeam wipes\n")
+	for _, p := range r.Players {
+		fmt.Fprintf(tw, "  %s\t%d\t%.0f\t%.0f\t%.0f\t%.0f\t%.0f\t%.0f\n", p.Username, p.Matches, p.CacheOpens, p.KioskBuys,
+			p.Revives, p.TabletPickups, p.MissionsComplete, p.TeamWipes)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	roles := []struct{ title, player string }{
+		{"Looter", r.Roles.Looter},
+		{"Shopper", r.Roles.Shopper},
+		{"Medic", r.Roles.Medic},
+		{"Contracts", r.Roles.Contracts},
+		{"Wiper", r.Roles.Wiper},
+	}
+	for _, role := range roles {
+		if role.player != "" {
+			fmt.Fprintf(w, "  %s: %s\n", role.title, role.player)
+		}
+	}
+	return nil
+}
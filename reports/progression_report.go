@@ -0,0 +1,25 @@
+package reports
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+)
+
+// RenderProgression write the level of the player, the xp rate and the xp breakdown by source.
+func RenderProgression(w io.Writer, p analytics.Progression) error {
+	fmt.Fprintf(w, "Progression: %s level %.0f (prestige %.0f), %.0f xp to the next level\n", p.Username, p.Level, p.Prestige, p.LevelXpRemainder)
+	fmt.Fprintf(w, "  %d games in %d sessions (%s): %.0f xp, %.0f xp/hour, %.0f xp/game\n",
+		p.Games, p.Sessions, p.PlayTime, p.XpEarned, p.XpPerHour, p.XpPerGame)
+	if p.TimeToNextLevel > 0 {
+		fmt.Fprintf(w, "  Next level in about %s of playing (%.1f games)\n", p.TimeToNextLevel, p.GamesToNextLevel)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Source\tXp\t%%\n")
+	for _, s := range p.Breakdown {
+		fmt.Fprintf(tw, "  %s\t%.0f\t%.1f\n", s.Source, s.Xp, s.Percent)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,42 @@
+// Package reports is responsible for rendering the analytics results into a readable output,
+// the render functions receive an io.Writer so the same report can be printed to the terminal or written into http response.
+
+package reports
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+)
+
+const reportTimeFormat = "2006-01-02 15:04"
+
+// RenderSessions write a small table for each session and the players breakdown of the session under it.
+func RenderSessions(w io.Writer, sessions []analytics.SessionReport) error {
+	for i, s := range sessions {
+		fmt.Fprintf(w, "Session %d: %s - %s (%s)\n", i+1, s.Start.Format(reportTimeFormat), s.End.Format(reportTimeFormat), s.TotalTime)
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "  Games\tWins\tKills\tDamage\tAvg placement\tBest game\n")
+		fmt.Fprintf(tw, "  %d\t%d\t%.0f\t%.0f\t%.1f\t%s (#%.0f)\n", s.Games, s.Wins, s.Kills, s.DamageDone, s.AveragePlacement, s.BestGame.MatchID, s.BestGame.TeamPlacement)
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if len(s.Players) > 1 {
+			tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+			fmt.Fprintf(tw, "  Player\tGames\tKills\tDeaths\tDamage\tAwards\n")
+			for _, p := range s.Players {
+				fmt.Fprintf(tw, "  %s\t%d\t%.0f\t%.0f\t%.0f\t%d\n", p.Username, p.Games, p.Kills, p.Deaths, p.DamageDone, p.Awards)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+		if s.Awards.Total > 0 {
+			fmt.Fprintf(w, "  Top awards: %s\n", awardCounts(s.Awards.MostFrequent))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
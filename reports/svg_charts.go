@@ -0,0 +1,165 @@
+// This file draws the charts of the html report as inline SVG, the report is a single file that is opened without
+// internet connection so we don't use any javascript chart library.
+
+package reports
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+	"time"
+)
+
+// The size of the charts in SVG units, the charts are scaled to the page width by the viewBox.
+const (
+	chartWidth   = 720
+	chartHeight  = 260
+	chartPadLeft = 56
+	chartPadTop  = 16
+	chartPadSide = 16
+	chartPadDown = 36
+	gridLines    = 4
+)
+
+// chartColors are the colors of the series by their order.
+var chartColors = []string{"#e4572e", "#4c9be8", "#f3a712", "#76b041", "#a05195", "#2ec4b6", "#8d99ae", "#ff6f91"}
+
+// chartPoint is a single point of a line chart, X is unix seconds.
+type chartPoint struct {
+	X float64
+	Y float64
+}
+
+// chartSeries is a line of a line chart.
+type chartSeries struct {
+	Name   string
+	Points []chartPoint
+}
+
+// lineChart draw the series over a time axis, the Y axis start from 0.
+func lineChart(series []chartSeries, yFormat string) template.HTML {
+	minX, maxX, maxY := math.Inf(1), math.Inf(-1), 0.0
+	for _, s := range series {
+		for _, p := range s.Points {
+			minX = math.Min(minX, p.X)
+			maxX = math.Max(maxX, p.X)
+			maxY = math.Max(maxY, p.Y)
+		}
+	}
+	if math.IsInf(minX, 1) {
+		return emptyChart()
+	}
+	if maxX == minX {
+		minX, maxX = minX-1800, maxX+1800
+	}
+	maxY = niceMax(maxY)
+	plotWidth := float64(chartWidth - chartPadLeft - chartPadSide)
+	plotHeight := float64(chartHeight - chartPadTop - chartPadDown)
+	x := func(v float64) float64 { return chartPadLeft + (v-minX)/(maxX-minX)*plotWidth }
+	y := func(v float64) float64 { return chartPadTop + plotHeight - v/maxY*plotHeight }
+
+	var b strings.Builder
+	openChart(&b)
+	drawGrid(&b, maxY, yFormat)
+	for i := 0; i <= gridLines; i++ {
+		v := minX + (maxX-minX)*float64(i)/gridLines
+		// The first and the last labels are aligned to the inside so they will not be cut by the chart edges.
+		anchor := "middle"
+		if i == 0 {
+			anchor = "start"
+		} else if i == gridLines {
+			anchor = "end"
+		}
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="%s" class="axis">%s</text>`,
+			x(v), chartHeight-chartPadDown+18, anchor, time.Unix(int64(v), 0).UTC().Format("Jan 2 15:04"))
+	}
+	for i, s := range series {
+		color := chartColors[i%len(chartColors)]
+		coordinates := make([]string, len(s.Points))
+		for j, p := range s.Points {
+			coordinates[j] = fmt.Sprintf("%.1f,%.1f", x(p.X), y(p.Y))
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`, color, strings.Join(coordinates, " "))
+		for _, p := range s.Points {
+			fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="2.5" fill="%s"><title>%s: `+yFormat+`</title></circle>`,
+				x(p.X), y(p.Y), color, template.HTMLEscapeString(s.Name), p.Y)
+		}
+	}
+	b.WriteString(`</svg>`)
+	b.WriteString(legend(series))
+	return template.HTML(b.String())
+}
+
+// barChart draw a bar for every label with its value above the bar.
+func barChart(labels []string, values []float64) template.HTML {
+	maxY := 0.0
+	for _, v := range values {
+		maxY = math.Max(maxY, v)
+	}
+	if len(values) == 0 {
+		return emptyChart()
+	}
+	maxY = niceMax(maxY)
+	plotWidth := float64(chartWidth - chartPadLeft - chartPadSide)
+	plotHeight := float64(chartHeight - chartPadTop - chartPadDown)
+	slot := plotWidth / float64(len(values))
+
+	var b strings.Builder
+	openChart(&b)
+	drawGrid(&b, maxY, "%.0f")
+	for i, v := range values {
+		height := v / maxY * plotHeight
+		left := chartPadLeft + slot*float64(i) + slot*0.15
+		top := chartPadTop + plotHeight - height
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, left, top, slot*0.7, height, chartColors[0])
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle" class="value">%.0f</text>`, left+slot*0.35, top-4, v)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle" class="axis">%s</text>`,
+			left+slot*0.35, chartHeight-chartPadDown+18, template.HTMLEscapeString(labels[i]))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+func openChart(b *strings.Builder) {
+	fmt.Fprintf(b, `<svg class="chart" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg" role="img">`, chartWidth, chartHeight)
+}
+
+// drawGrid draw the horizontal grid lines with the Y values.
+func drawGrid(b *strings.Builder, maxY float64, yFormat string) {
+	plotHeight := float64(chartHeight - chartPadTop - chartPadDown)
+	for i := 0; i <= gridLines; i++ {
+		v := maxY * float64(i) / gridLines
+		lineY := chartPadTop + plotHeight - plotHeight*float64(i)/gridLines
+		fmt.Fprintf(b, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="grid"/>`, chartPadLeft, lineY, chartWidth-chartPadSide, lineY)
+		fmt.Fprintf(b, `<text x="%d" y="%.1f" text-anchor="end" class="axis">`+yFormat+`</text>`, chartPadLeft-6, lineY+4, v)
+	}
+}
+
+func legend(series []chartSeries) string {
+	var b strings.Builder
+	b.WriteString(`<div class="legend">`)
+	for i, s := range series {
+		fmt.Fprintf(&b, `<span><i style="background:%s"></i>%s</span>`, chartColors[i%len(chartColors)], template.HTMLEscapeString(s.Name))
+	}
+	b.WriteString(`</div>`)
+	return b.String()
+}
+
+func emptyChart() template.HTML {
+	return template.HTML(`<p class="empty">No games</p>`)
+}
+
+// niceMax round the maximum value of the axis up to 1, 2, 2.5 or 5 times power of 10 so the grid values are round.
+func niceMax(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, step := range []float64{1, 2, 2.5, 5, 10} {
+		if v <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
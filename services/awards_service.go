@@ -0,0 +1,57 @@
+// This file is responsible for the awards summaries of the players over their recent or saved matches.
+
+package services
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+)
+
+// GetRecentAwards fetch the 'games' most recent matches of every player concurrently and aggregate the awards of each player,
+// in case one of the requests failed we will return the first error that we received.
+func GetRecentAwards(players []activision.LastGamesRequest, games int) ([]analytics.PlayerAwards, error) {
+	if len(players) == 0 {
+		return nil, &activision.ActivisionErrorResponse{Message: "Error: at least one player is needed for the awards\n", StatusCode: 400}
+	}
+	responses := make([]*activision.LastGamesResponse, len(players))
+	errs := make([]error, len(players))
+	var wg sync.WaitGroup
+	for i, p := range players {
+		wg.Add(1)
+		go func(i int, p activision.LastGamesRequest) {
+			defer wg.Done()
+			responses[i], errs[i] = GetRecentMatches(p, games)
+		}(i, p)
+	}
+	wg.Wait()
+	result := make([]analytics.PlayerAwards, 0, len(players))
+	for i, r := range responses {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		result = append(result, analytics.PlayerAwards{Player: store.PlayerKey(r.Platform, r.Username), Awards: analytics.MatchesAwards(r.Data.Matches)})
+	}
+	return result, nil
+}
+
+// GetSavedAwards aggregate the awards of the saved matches of every player, when no player is given all the tracked players are used.
+func GetSavedAwards(players []activision.LastGamesRequest) ([]analytics.PlayerAwards, error) {
+	saved, err := savedMatches(players)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(saved))
+	for key := range saved {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]analytics.PlayerAwards, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, analytics.PlayerAwards{Player: key, Awards: analytics.MatchesAwards(saved[key])})
+	}
+	return result, nil
+}
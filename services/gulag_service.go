@@ -0,0 +1,18 @@
+// This file is responsible for the gulag analytics of the tracked players.
+
+package services
+
+import (
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// GetSquadGulag return the gulag analytics of the players from their saved matches, when no player is given all the tracked players are used.
+func GetSquadGulag(players []activision.LastGamesRequest) (*analytics.SquadGulagReport, error) {
+	matches, err := savedMatches(players)
+	if err != nil {
+		return nil, err
+	}
+	report := analytics.SquadGulag(matches)
+	return &report, nil
+}
@@ -0,0 +1,62 @@
+// This file is responsible for the leaderboards of the tracked players, the tracked players are the players that have
+// saved matches in the store (the watch mode fill it).
+
+package services
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+)
+
+// GetLeaderboard rank all the tracked players by the metric over the window, for the lifetime window the lifetime stats of
+// the players are fetched concurrently, a player that we failed to fetch is ranked by his saved matches and his entry is
+// marked with LifetimeFailed. The window and the metric are validated first so an invalid request does not fetch anything.
+func GetLeaderboard(window string, metric string) (*analytics.Leaderboard, error) {
+	if err := analytics.ValidateLeaderboard(window, metric); err != nil {
+		return nil, err
+	}
+	matches, err := savedMatches(nil)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(matches))
+	for key := range matches {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	players := make([]analytics.LeaderboardPlayer, len(keys))
+	for i, key := range keys {
+		players[i] = analytics.LeaderboardPlayer{Player: key, Matches: matches[key]}
+	}
+	if window == analytics.WindowLifetime {
+		var wg sync.WaitGroup
+		for i := range players {
+			platform, username, _ := strings.Cut(players[i].Player, ":")
+			wg.Add(1)
+			go func(i int, r activision.LifetimeAndWeeklyRequest) {
+				defer wg.Done()
+				lifetime, err := activision_providers.GetLifetimeAndWeeklyStats(r)
+				if err != nil {
+					slog.Warn("failed to get the lifetime stats, the player is ranked by the saved matches",
+						slog.String("player", players[i].Player), slog.String("error", strings.TrimSpace(err.Error())))
+					players[i].LifetimeFailed = true
+					return
+				}
+				players[i].Lifetime = lifetime
+			}(i, activision.LifetimeAndWeeklyRequest{Username: username, Platform: platform})
+		}
+		wg.Wait()
+	}
+	board, err := analytics.BuildLeaderboard(players, window, metric, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
@@ -0,0 +1,17 @@
+// This file is responsible for the map breakdown of the tracked players.
+
+package services
+
+import (
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// GetSquadMaps return the performance of the players on every map from their saved matches, when no player is given all the tracked players are used.
+func GetSquadMaps(players []activision.LastGamesRequest) ([]analytics.MapStats, error) {
+	matches, err := savedMatches(players)
+	if err != nil {
+		return nil, err
+	}
+	return analytics.SquadMatchesByMap(matches), nil
+}
@@ -0,0 +1,70 @@
+// This file is responsible for the enrichment of the last games with the full lobby of every match.
+
+package services
+
+import (
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+)
+
+// DefaultEnrichConcurrency is the number of full match requests that are sent at the same time when the options does not set it,
+// enrichment of 20 games is 20 requests so we keep it low to not get blocked by the official API.
+const DefaultEnrichConcurrency = 4
+
+// EnrichOptions are the options of EnrichMatches.
+type EnrichOptions struct {
+	Concurrency int
+}
+
+// EnrichMatches fetch the full lobby of every match of the last games response and attach the stats of the teammates and the lobby aggregates,
+// matches that failed are returned with their Error field set. An error is returned only when all the matches failed.
+func EnrichMatches(r *activision.LastGamesResponse, options EnrichOptions) ([]analytics.EnrichedMatch, error) {
+	if r == nil {
+		return nil, &activision.ActivisionErrorResponse{Message: "Error: the last games response is required\n", StatusCode: 400}
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultEnrichConcurrency
+	}
+	matches := r.Data.Matches
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.MatchID
+	}
+	fulls, errs := getFullMatches(ids, concurrency)
+
+	result := make([]analytics.EnrichedMatch, len(matches))
+	failed := 0
+	for i, m := range matches {
+		if errs[i] != nil {
+			failed++
+			result[i] = analytics.EnrichedMatch{Match: m, Error: errs[i].Error()}
+			continue
+		}
+		result[i] = analytics.EnrichMatch(m, *fulls[i])
+	}
+	if failed > 0 && failed == len(matches) {
+		return nil, errs[0]
+	}
+	return result, nil
+}
+
+// GetFullMatches return the full matches of the IDs, the results and the errors are in the same order of the IDs.
+// The matches are cached by the cache middleware of the providers when it is registered, every result is a copy that
+// the caller can change.
+func GetFullMatches(ids []string) ([]*activision.SpecificGameStatsResponse, []error) {
+	return getFullMatches(ids, DefaultEnrichConcurrency)
+}
+
+// getFullMatches fetch the full matches with at most 'concurrency' requests at the same time,
+// the results and the errors are in the same order of the IDs.
+func getFullMatches(ids []string, concurrency int) ([]*activision.SpecificGameStatsResponse, []error) {
+	result := make([]*activision.SpecificGameStatsResponse, len(ids))
+	errs := make([]error, len(ids))
+	fetched := activision_providers.GetGamesStatsByIDs(ids, activision_providers.BulkOptions{Workers: concurrency})
+	for i, id := range ids {
+		result[i], errs[i] = fetched.Results[id], fetched.Errors[id]
+	}
+	return result, errs
+}
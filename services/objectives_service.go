@@ -0,0 +1,43 @@
+// This file is responsible for the objectives analytics of the squad of a player.
+
+package services
+
+import (
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// GetSquadObjectives fetch the last games of the player and their full matches and return the objectives of the player and
+// his teammates, matches that we failed to fetch are skipped.
+func GetSquadObjectives(player activision.LastGamesRequest, games int) (*analytics.SquadObjectivesReport, error) {
+	if games <= 0 {
+		games = gamesPerRequest
+	}
+	lastGames, err := getLastGames(player, games)
+	if err != nil {
+		return nil, err
+	}
+	matches := lastGames.Data.Matches
+	if len(matches) > games {
+		matches = matches[:games]
+	}
+	if len(matches) == 0 {
+		return &analytics.SquadObjectivesReport{}, nil
+	}
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.MatchID
+	}
+	fulls, errs := getFullMatches(ids, DefaultEnrichConcurrency)
+	var received []activision.SpecificGameStatsResponse
+	for i, full := range fulls {
+		if errs[i] == nil {
+			received = append(received, *full)
+		}
+	}
+	if len(received) == 0 {
+		return nil, errs[0]
+	}
+	report := analytics.MatchesObjectives(received, matches[0].Player.Uno)
+	return &report, nil
+}
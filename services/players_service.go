@@ -0,0 +1,87 @@
+// Package services combine the activision providers with the analytics package, the functions here are the ones
+// that the CLI and the http controllers use, they send the requests that needed and return the ready result.
+
+package services
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+)
+
+// gamesPerRequest is the number of games that the official API return for a single last games request.
+const gamesPerRequest = 20
+
+// ParsePlayer convert player string in the format "platform:username" (for example "uno:nivGolanigo#1234") into LastGamesRequest,
+// the CLI and the http controllers receive the players in this format.
+func ParsePlayer(s string) (activision.LastGamesRequest, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return activision.LastGamesRequest{}, activision.NewValidationError("player", s, "player must be in the format platform:username")
+	}
+	return activision.LastGamesRequest{Platform: parts[0], Username: parts[1]}, nil
+}
+
+// ComparePlayers fetch the lifetime&weekly stats and the last games of every player concurrently and return the side by side comparison,
+// the players argument accept LastGamesRequest or LifetimeAndWeeklyRequest objects because we only need the username and the platform.
+// In case one of the requests failed we will return the first error that we received.
+func ComparePlayers(players []activision.ActivisionRequest, games int) (*analytics.PlayersComparison, error) {
+	if len(players) < 2 {
+		return nil, &activision.ActivisionErrorResponse{Message: "Error: at least two players are needed for comparison\n", StatusCode: 400}
+	}
+	if games <= 0 {
+		games = gamesPerRequest
+	}
+
+	snapshots := make([]analytics.PlayerSnapshot, len(players))
+	errs := make([]error, 2*len(players))
+	var wg sync.WaitGroup
+	for i, p := range players {
+		snapshots[i] = analytics.PlayerSnapshot{Username: p.GetUsername(), Platform: p.GetPlatform()}
+		wg.Add(2)
+		go func(i int, p activision.ActivisionRequest) {
+			defer wg.Done()
+			snapshots[i].LifetimeAndWeekly, errs[2*i] = activision_providers.GetLifetimeAndWeeklyStats(activision.LifetimeAndWeeklyRequest{Username: p.GetUsername(), Platform: p.GetPlatform()})
+		}(i, p)
+		go func(i int, p activision.ActivisionRequest) {
+			defer wg.Done()
+			snapshots[i].LastGames, errs[2*i+1] = getLastGames(activision.LastGamesRequest{Username: p.GetUsername(), Platform: p.GetPlatform()}, games)
+		}(i, p)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := analytics.ComparePlayersStats(snapshots, games)
+	return &result, nil
+}
+
+// getLastGames return at least 'games' recent matches, when more then 20 games are needed we use the cycles method.
+func getLastGames(r activision.LastGamesRequest, games int) (*activision.LastGamesResponse, error) {
+	if games <= gamesPerRequest {
+		return activision_providers.GetLastGamesStats(r)
+	}
+	cycles := (games + gamesPerRequest - 1) / gamesPerRequest
+	return activision_providers.GetLastGamesStatsByCycles(r, cycles)
+}
+
+// GetRecentMatches return the 'games' most recent matches of the player (20 when games <= 0).
+func GetRecentMatches(player activision.LastGamesRequest, games int) (*activision.LastGamesResponse, error) {
+	if games <= 0 {
+		games = gamesPerRequest
+	}
+	result, err := getLastGames(player, games)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data.Matches) > games {
+		result.Data.Matches = result.Data.Matches[:games]
+	}
+	return result, nil
+}
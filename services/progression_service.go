@@ -0,0 +1,33 @@
+// This file is responsible for the xp progression of a player.
+
+package services
+
+import (
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+)
+
+// GetProgression fetch the profile and the recent games of the player concurrently and return his progression.
+func GetProgression(player activision.LastGamesRequest, games int) (*analytics.Progression, error) {
+	if games <= 0 {
+		games = gamesPerRequest
+	}
+	var profile *activision.LifetimeAndWeeklyResponse
+	var profileErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		profile, profileErr = activision_providers.GetLifetimeAndWeeklyStats(activision.LifetimeAndWeeklyRequest{Username: player.Username, Platform: player.Platform})
+	}()
+	recent, err := getLastGames(player, games)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+	if profileErr != nil {
+		return nil, profileErr
+	}
+	result := analytics.BuildProgression(*profile, *recent, 0)
+	return &result, nil
+}
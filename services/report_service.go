@@ -0,0 +1,51 @@
+// This file is responsible for the data of the html report of a player or a squad.
+
+package services
+
+import (
+	"sync"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+)
+
+// GetRecentMatchesReport fetch the 'games' most recent matches of every player concurrently and build the report,
+// the players of the report are named by platform:username.
+// In case one of the requests failed we will return the first error that we received.
+func GetRecentMatchesReport(players []activision.LastGamesRequest, games int, options analytics.ReportOptions) (*analytics.MatchesReport, error) {
+	if len(players) == 0 {
+		return nil, &activision.ActivisionErrorResponse{Message: "Error: at least one player is needed for report\n", StatusCode: 400}
+	}
+	responses := make([]*activision.LastGamesResponse, len(players))
+	errs := make([]error, len(players))
+	var wg sync.WaitGroup
+	for i, p := range players {
+		wg.Add(1)
+		go func(i int, p activision.LastGamesRequest) {
+			defer wg.Done()
+			responses[i], errs[i] = GetRecentMatches(p, games)
+		}(i, p)
+	}
+	wg.Wait()
+	matches := make(map[string][]activision.Match)
+	for i, r := range responses {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		matches[store.PlayerKey(r.Platform, r.Username)] = r.Data.Matches
+	}
+	report := analytics.BuildMatchesReport(matches, options)
+	return &report, nil
+}
+
+// GetSavedMatchesReport build the report from the saved matches of the players, when no player is given all the tracked players are used.
+func GetSavedMatchesReport(players []activision.LastGamesRequest, options analytics.ReportOptions) (*analytics.MatchesReport, error) {
+	// The players are kept by their platform:username store key, the same username can be used on two platforms.
+	matches, err := savedMatches(players)
+	if err != nil {
+		return nil, err
+	}
+	report := analytics.BuildMatchesReport(matches, options)
+	return &report, nil
+}
@@ -0,0 +1,42 @@
+// This file is responsible for the sessions of a squad.
+
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/analytics"
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+)
+
+// GetSquadSessions fetch the last games of all the squad members concurrently and return the reports of the squad sessions,
+// in case one of the requests failed we will return the first error that we received.
+func GetSquadSessions(players []activision.LastGamesRequest, gap time.Duration) ([]analytics.SessionReport, error) {
+	if len(players) == 0 {
+		return nil, &activision.ActivisionErrorResponse{Message: "Error: at least one player is needed for squad report\n", StatusCode: 400}
+	}
+	responses := make([]activision.LastGamesResponse, len(players))
+	errs := make([]error, len(players))
+	var wg sync.WaitGroup
+	for i, p := range players {
+		wg.Add(1)
+		go func(i int, p activision.LastGamesRequest) {
+			defer wg.Done()
+			result, err := activision_providers.GetLastGamesStats(p)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			responses[i] = *result
+		}(i, p)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return analytics.SessionsReports(analytics.BuildSquadSessions(responses, gap)), nil
+}
@@ -0,0 +1,46 @@
+// This file is responsible for reading the matches that the watch mode saved in the store.
+
+package services
+
+import (
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+)
+
+var matchesStore store.Store
+
+// SetStore set the store that the services read the saved matches from.
+func SetStore(s store.Store) {
+	matchesStore = s
+}
+
+// savedMatches return the saved matches of the players by their store key, when no player is given all the tracked players are returned.
+func savedMatches(players []activision.LastGamesRequest) (map[string][]activision.Match, error) {
+	if matchesStore == nil {
+		return nil, &activision.ActivisionErrorResponse{Message: "Error: the matches store is not configured\n", StatusCode: 500}
+	}
+	var keys []string
+	for _, p := range players {
+		normalized, err := activision_providers.NormalizeRequest(p)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, store.PlayerKey(normalized.Platform, normalized.Username))
+	}
+	if len(keys) == 0 {
+		var err error
+		if keys, err = matchesStore.Players(); err != nil {
+			return nil, err
+		}
+	}
+	matches := make(map[string][]activision.Match)
+	for _, key := range keys {
+		saved, err := matchesStore.Matches(key)
+		if err != nil {
+			return nil, err
+		}
+		matches[key] = saved
+	}
+	return matches, nil
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// MemoryStore keep the matches only in the memory, it is used when the matches are needed only while the process is running.
+type MemoryStore struct {
+	mutex   sync.Mutex
+	players map[string]map[string]activision.Match
+}
+
+// NewMemoryStore return empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{players: make(map[string]map[string]activision.Match)}
+}
+
+func (s *MemoryStore) SaveMatches(player string, matches []activision.Match) ([]activision.Match, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	saved, ok := s.players[player]
+	if !ok {
+		saved = make(map[string]activision.Match)
+		s.players[player] = saved
+	}
+	var added []activision.Match
+	for _, m := range matches {
+		if _, ok := saved[m.MatchID]; ok || m.MatchID == "" {
+			continue
+		}
+		saved[m.MatchID] = m
+		added = append(added, m)
+	}
+	return added, nil
+}
+
+func (s *MemoryStore) Matches(player string) ([]activision.Match, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return sortedMatches(s.players[player]), nil
+}
+
+func (s *MemoryStore) Players() ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	players := make([]string, 0, len(s.players))
+	for player := range s.players {
+		players = append(players, player)
+	}
+	sort.Strings(players)
+	return players, nil
+}
@@ -0,0 +1,199 @@
+// Package store is responsible for saving the matches that we already received from the official API on the local disk,
+// the official API return only the last 20 games for each request so the store is the way to keep the history of the players.
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// Store keep the matches of each player, the player key is "platform:username" after the normalization.
+type Store interface {
+	// SaveMatches save the matches of the player and return only the matches that was not saved before.
+	SaveMatches(player string, matches []activision.Match) ([]activision.Match, error)
+	// Matches return all the saved matches of the player sorted from the newest match.
+	Matches(player string) ([]activision.Match, error)
+	// Players return the keys of all the players that have saved matches.
+	Players() ([]string, error)
+}
+
+// PlayerKey build the store key of the player.
+func PlayerKey(platform string, username string) string {
+	return platform + ":" + username
+}
+
+// FileStore save the matches of each player in a json file inside the store directory.
+// The matches are kept in memory and the file is read again when it was changed on the disk, for example by a watch
+// process that runs next to the http server.
+type FileStore struct {
+	dir      string
+	mutex    sync.Mutex
+	players  map[string]map[string]activision.Match // player key -> match ID -> match
+	versions map[string]fileVersion                 // player key -> the version of the file that the matches was read from
+}
+
+// fileVersion identify the content of the file without reading it, the size is compared too because the modification time
+// of two writes in a row can be the same.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewFileStore create the store directory if needed and return FileStore that use it.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir, players: make(map[string]map[string]activision.Match), versions: make(map[string]fileVersion)}, nil
+}
+
+func (s *FileStore) SaveMatches(player string, matches []activision.Match) ([]activision.Match, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	saved, err := s.load(player)
+	if err != nil {
+		return nil, err
+	}
+	var added []activision.Match
+	for _, m := range matches {
+		if _, ok := saved[m.MatchID]; ok || m.MatchID == "" {
+			continue
+		}
+		saved[m.MatchID] = m
+		added = append(added, m)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	if err := s.write(player, saved); err != nil {
+		// We remove the new matches from the memory so they will be reported again in the next save.
+		for _, m := range added {
+			delete(saved, m.MatchID)
+		}
+		return nil, err
+	}
+	return added, nil
+}
+
+func (s *FileStore) Matches(player string) ([]activision.Match, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	saved, err := s.load(player)
+	if err != nil {
+		return nil, err
+	}
+	return sortedMatches(saved), nil
+}
+
+func (s *FileStore) Players() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var players []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), matchesFileSuffix) {
+			continue
+		}
+		player, err := url.QueryUnescape(strings.TrimSuffix(e.Name(), matchesFileSuffix))
+		if err != nil {
+			continue
+		}
+		players = append(players, player)
+	}
+	sort.Strings(players)
+	return players, nil
+}
+
+/***************************************** Help functions for the files *****************************************/
+
+const matchesFileSuffix = ".matches.json"
+
+// playerFile return the file path of the player, the key is escaped because usernames can contain any character.
+func (s *FileStore) playerFile(player string) string {
+	return filepath.Join(s.dir, url.QueryEscape(player)+matchesFileSuffix)
+}
+
+// load return the matches of the player from the memory, they are read from the disk on the first time and every time
+// that the file was changed since we read it.
+func (s *FileStore) load(player string) (map[string]activision.Match, error) {
+	version, err := s.fileVersion(player)
+	if err != nil {
+		return nil, err
+	}
+	if saved, ok := s.players[player]; ok && s.versions[player] == version {
+		return saved, nil
+	}
+	saved := make(map[string]activision.Match)
+	data, err := os.ReadFile(s.playerFile(player))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		var matches []activision.Match
+		if err := json.Unmarshal(data, &matches); err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			saved[m.MatchID] = m
+		}
+	}
+	s.players[player] = saved
+	s.versions[player] = version
+	return saved, nil
+}
+
+// fileVersion return the version of the player file, the zero version when the file does not exist.
+func (s *FileStore) fileVersion(player string) (fileVersion, error) {
+	info, err := os.Stat(s.playerFile(player))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileVersion{}, nil
+	}
+	if err != nil {
+		return fileVersion{}, err
+	}
+	return fileVersion{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// write save the matches of the player into temporary file and then rename it, that way a crash in the middle
+// of the write will not leave broken file.
+func (s *FileStore) write(player string, saved map[string]activision.Match) error {
+	data, err := json.Marshal(sortedMatches(saved))
+	if err != nil {
+		return err
+	}
+	tmp := s.playerFile(player) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.playerFile(player)); err != nil {
+		return err
+	}
+	// We already have the new content in the memory so there is no need to read the file again.
+	version, err := s.fileVersion(player)
+	if err != nil {
+		return err
+	}
+	s.versions[player] = version
+	return nil
+}
+
+func sortedMatches(saved map[string]activision.Match) []activision.Match {
+	matches := make([]activision.Match, 0, len(saved))
+	for _, m := range saved {
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].UtcStartSeconds > matches[j].UtcStartSeconds })
+	return matches
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// Two stores over the same directory are like the watch process and the http server, each one must see the matches
+// that the other saved after it already read the player file.
+func TestFileStoreReloadChangedFile(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player := PlayerKey("uno", "inbargab#6797419")
+
+	if _, err := writer.SaveMatches(player, []activision.Match{{MatchID: "1", UtcStartSeconds: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := reader.Matches(player)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(matches))
+	}
+
+	if _, err := writer.SaveMatches(player, []activision.Match{{MatchID: "2", UtcStartSeconds: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	matches, err = reader.Matches(player)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 || matches[0].MatchID != "2" {
+		t.Fatalf("expected the new match to be read from the disk, got %+v", matches)
+	}
+
+	added, err := reader.SaveMatches(player, []activision.Match{{MatchID: "2"}, {MatchID: "3", UtcStartSeconds: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 1 || added[0].MatchID != "3" {
+		t.Fatalf("expected only match 3 to be added, got %+v", added)
+	}
+}
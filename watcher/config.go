@@ -0,0 +1,69 @@
+// This file is responsible for reading the watch config file, example:
+//
+//	{
+//	  "interval": "5m",
+//	  "jitter": "30s",
+//	  "notifyExisting": false,
+//	  "squads": [
+//	    {"name": "the boys", "players": [{"username": "nivGolanigo#1234", "platform": "battle"}, {"username": "inbargab#6797419", "platform": "uno"}]}
+//	  ]
+//	}
+
+package watcher
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+)
+
+// FileConfig is the structure of the watch config file, the durations are written in the time.ParseDuration format.
+type FileConfig struct {
+	Interval       string  `json:"interval"`
+	Jitter         string  `json:"jitter"`
+	NotifyExisting bool    `json:"notifyExisting"`
+	Squads         []Squad `json:"squads"`
+}
+
+// LoadConfig read the config file and return the Config without the store and the sinks that the caller need to set.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	var file FileConfig
+	if err := json.Unmarshal(data, &file); err != nil {
+		return config, err
+	}
+	if config.Interval, err = parseDuration("interval", file.Interval); err != nil {
+		return config, err
+	}
+	if config.Jitter, err = parseDuration("jitter", file.Jitter); err != nil {
+		return config, err
+	}
+	config.NotifyExisting = file.NotifyExisting
+	config.Squads = file.Squads
+	for i, squad := range config.Squads {
+		if len(squad.Players) == 0 {
+			return config, activision.NewValidationError("squads", squad.Name, "squad must have at least one player")
+		}
+		if squad.Name == "" {
+			config.Squads[i].Name = squad.Players[0].Username
+		}
+	}
+	return config, nil
+}
+
+func parseDuration(field string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, activision.NewValidationError(field, value, "must be duration like 5m or 30s")
+	}
+	return d, nil
+}
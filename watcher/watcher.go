@@ -0,0 +1,216 @@
+// Package watcher implements the long running 'watch' mode, the watcher hold a list of tracked squads (a single player
+// is a squad of one), poll their last games on a schedule, save the matches in the store and emit event for every match
+// that was not seen before.
+
+package watcher
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/providers/activision_providers"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+)
+
+// DefaultInterval is the time between two polls of the same squad when the config does not set it.
+const DefaultInterval = 5 * time.Minute
+
+// Squad is a group of players that we track together, matches that the squad members played together are reported as one event.
+type Squad struct {
+	Name    string                        `json:"name"`
+	Players []activision.LastGamesRequest `json:"players"`
+}
+
+// PlayerMatch is the stats of a single squad member from the match.
+type PlayerMatch struct {
+	Username string                          `json:"username"`
+	Platform string                          `json:"platform"`
+	Stats    activision.PlayerStatsFromMatch `json:"stats"`
+	Awards   activision.Awards               `json:"awards"`
+}
+
+// MatchEvent is emitted for every new finished match of a squad, Match is the match as the first squad member received it
+// and Players holds the stats of all the squad members that played in it.
+type MatchEvent struct {
+	Squad      string           `json:"squad"`
+	Match      activision.Match `json:"match"`
+	Players    []PlayerMatch    `json:"players"`
+	DetectedAt time.Time        `json:"detectedAt"`
+}
+
+// Sink receive the new match events, the watcher call the sinks one after the other so a sink should not block for long.
+type Sink interface {
+	Notify(ctx context.Context, event MatchEvent) error
+}
+
+// SinkFunc allow to use a function as Sink.
+type SinkFunc func(ctx context.Context, event MatchEvent) error
+
+func (f SinkFunc) Notify(ctx context.Context, event MatchEvent) error {
+	return f(ctx, event)
+}
+
+// LogSink write every event to the default logger.
+var LogSink = SinkFunc(func(ctx context.Context, event MatchEvent) error {
+	slog.InfoContext(ctx, "new match", slog.String("squad", event.Squad), slog.String("match_id", event.Match.MatchID),
+		slog.String("mode", event.Match.Mode), slog.Float64("placement", event.Match.PlayerStats.TeamPlacement), slog.Int("players", len(event.Players)))
+	return nil
+})
+
+// Config holds the settings of the watcher.
+// When NotifyExisting is false the first poll of a player that has no saved matches only fill the store without events,
+// otherwise we will send event for each of his last 20 games when we start to track him.
+// Fetch is the function that return the player last games, when it is nil GetLastGamesStats is used.
+type Config struct {
+	Squads         []Squad
+	Interval       time.Duration
+	Jitter         time.Duration
+	Store          store.Store
+	Sinks          []Sink
+	NotifyExisting bool
+	Fetch          func(r activision.LastGamesRequest) (*activision.LastGamesResponse, error)
+}
+
+// Watcher poll the tracked squads until its context is canceled.
+type Watcher struct {
+	config Config
+}
+
+// New create watcher from the config.
+func New(config Config) *Watcher {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.Fetch == nil {
+		config.Fetch = activision_providers.GetLastGamesStats
+	}
+	return &Watcher{config: config}
+}
+
+// Run poll all the squads immediately and then every Interval plus random jitter, Run return when the context is canceled
+// after the current poll finished, that way a SIGINT will not leave half saved matches.
+func (w *Watcher) Run(ctx context.Context) error {
+	for {
+		w.PollOnce(ctx)
+		wait := w.config.Interval
+		if w.config.Jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(w.config.Jitter)))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// PollOnce poll all the squads one time, the requests are sent one after the other so the providers rate limiter is respected.
+// Errors of a single player are logged and do not stop the poll of the others.
+func (w *Watcher) PollOnce(ctx context.Context) {
+	for _, squad := range w.config.Squads {
+		if ctx.Err() != nil {
+			return
+		}
+		for _, event := range w.pollSquad(ctx, squad) {
+			w.emit(ctx, event)
+		}
+	}
+}
+
+// polledPlayer is the last games of a squad member with the IDs of his matches that were saved before the poll.
+type polledPlayer struct {
+	key      string
+	response *activision.LastGamesResponse
+	saved    map[string]bool
+}
+
+// pollSquad fetch the last games of every squad member, save them and return one event for each new match ID.
+// The official API can return a match for one member a poll before it return it for the others, so a match that another
+// member already saved before this poll was emitted with him and it is not emitted again.
+func (w *Watcher) pollSquad(ctx context.Context, squad Squad) []MatchEvent {
+	var polled []polledPlayer
+	for _, player := range squad.Players {
+		if ctx.Err() != nil {
+			break
+		}
+		p, err := w.pollPlayer(player)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to poll player", slog.String("squad", squad.Name), slog.String("error", err.Error()))
+			continue
+		}
+		polled = append(polled, p)
+	}
+
+	events := make(map[string]*MatchEvent)
+	var order []string
+	for i, p := range polled {
+		added, err := w.config.Store.SaveMatches(p.key, p.response.Data.Matches)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to save the player matches", slog.String("squad", squad.Name), slog.String("error", err.Error()))
+			continue
+		}
+		// The first poll of a player only fill the store, unless we were asked to notify about his existing matches.
+		if !w.config.NotifyExisting && len(p.saved) == 0 {
+			continue
+		}
+		for _, m := range added {
+			event, ok := events[m.MatchID]
+			if !ok {
+				if savedByOther(polled, i, m.MatchID) {
+					continue
+				}
+				event = &MatchEvent{Squad: squad.Name, Match: m, DetectedAt: time.Now()}
+				events[m.MatchID] = event
+				order = append(order, m.MatchID)
+			}
+			event.Players = append(event.Players, PlayerMatch{Username: p.response.Username, Platform: p.response.Platform, Stats: m.PlayerStats, Awards: m.Player.Awards})
+		}
+	}
+	result := make([]MatchEvent, 0, len(order))
+	for _, id := range order {
+		result = append(result, *events[id])
+	}
+	return result
+}
+
+// pollPlayer fetch the player last games and read the IDs of the matches that we already saved for him.
+func (w *Watcher) pollPlayer(player activision.LastGamesRequest) (polledPlayer, error) {
+	response, err := w.config.Fetch(player)
+	if err != nil {
+		return polledPlayer{}, err
+	}
+	key := store.PlayerKey(response.Platform, response.Username)
+	saved, err := w.config.Store.Matches(key)
+	if err != nil {
+		return polledPlayer{}, err
+	}
+	p := polledPlayer{key: key, response: response, saved: make(map[string]bool, len(saved))}
+	for _, m := range saved {
+		p.saved[m.MatchID] = true
+	}
+	return p, nil
+}
+
+// savedByOther return true when a squad member other than polled[i] saved the match before the poll.
+func savedByOther(polled []polledPlayer, i int, matchID string) bool {
+	for j, p := range polled {
+		if j != i && p.saved[matchID] {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) emit(ctx context.Context, event MatchEvent) {
+	for _, sink := range w.config.Sinks {
+		if err := sink.Notify(ctx, event); err != nil {
+			slog.WarnContext(ctx, "failed to send match event", slog.String("squad", event.Squad),
+				slog.String("match_id", event.Match.MatchID), slog.String("error", err.Error()))
+		}
+	}
+}
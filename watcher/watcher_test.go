@@ -0,0 +1,129 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/NivNagli/WarzoneSquad_Go/domain/activision"
+	"github.com/NivNagli/WarzoneSquad_Go/store"
+)
+
+// fakeFetcher return for every poll the scripted match IDs of each player, polls after the last one repeat it.
+type fakeFetcher struct {
+	polls []map[string][]string
+	poll  int
+}
+
+func (f *fakeFetcher) fetch(r activision.LastGamesRequest) (*activision.LastGamesResponse, error) {
+	poll := f.polls[min(f.poll, len(f.polls)-1)]
+	response := &activision.LastGamesResponse{Status: "success", Username: r.Username, Platform: r.Platform}
+	for i, id := range poll[r.Username] {
+		response.Data.Matches = append(response.Data.Matches, activision.Match{MatchID: id, UtcStartSeconds: float64(100 - i)})
+	}
+	return response, nil
+}
+
+// eventsString return the events as "matchID:player+player" joined with spaces, for compact expectations.
+func eventsString(events []MatchEvent) string {
+	var parts []string
+	for _, event := range events {
+		var players []string
+		for _, p := range event.Players {
+			players = append(players, p.Username)
+		}
+		parts = append(parts, event.Match.MatchID+":"+strings.Join(players, "+"))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestPollOnce(t *testing.T) {
+	tests := []struct {
+		name           string
+		players        []string
+		notifyExisting bool
+		saved          map[string][]string
+		polls          []map[string][]string
+		want           []string
+	}{
+		{
+			name:    "first poll only fill the store",
+			players: []string{"a"},
+			polls:   []map[string][]string{{"a": {"1", "2"}}},
+			want:    []string{""},
+		},
+		{
+			name:           "first poll with notify existing",
+			players:        []string{"a"},
+			notifyExisting: true,
+			polls:          []map[string][]string{{"a": {"1", "2"}}},
+			want:           []string{"1:a 2:a"},
+		},
+		{
+			name:    "dedup by match ID",
+			players: []string{"a"},
+			polls:   []map[string][]string{{"a": {"1"}}, {"a": {"2", "1"}}, {"a": {"2", "1"}}},
+			want:    []string{"", "2:a", ""},
+		},
+		{
+			name:    "same match in one poll is merged to one event",
+			players: []string{"a", "b"},
+			saved:   map[string][]string{"a": {"0"}, "b": {"0"}},
+			polls:   []map[string][]string{{"a": {"1", "0"}, "b": {"1", "2", "0"}}},
+			want:    []string{"1:a+b 2:b"},
+		},
+		{
+			name:    "match that show up for the second member a poll later is emitted once",
+			players: []string{"a", "b"},
+			saved:   map[string][]string{"a": {"0"}, "b": {"0"}},
+			polls:   []map[string][]string{{"a": {"1", "0"}, "b": {"0"}}, {"a": {"1", "0"}, "b": {"1", "0"}}},
+			want:    []string{"1:a", ""},
+		},
+		{
+			name:    "first poll of a new squad member does not emit the squad matches",
+			players: []string{"a", "b"},
+			saved:   map[string][]string{"a": {"0"}},
+			polls:   []map[string][]string{{"a": {"1", "0"}, "b": {"1", "0"}}, {"a": {"2", "1"}, "b": {"2", "1"}}},
+			want:    []string{"1:a", "2:a+b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matchesStore := store.NewMemoryStore()
+			for username, ids := range tt.saved {
+				var matches []activision.Match
+				for _, id := range ids {
+					matches = append(matches, activision.Match{MatchID: id})
+				}
+				if _, err := matchesStore.SaveMatches(store.PlayerKey("psn", username), matches); err != nil {
+					t.Fatal(err)
+				}
+			}
+			squad := Squad{Name: "squad"}
+			for _, username := range tt.players {
+				squad.Players = append(squad.Players, activision.LastGamesRequest{Username: username, Platform: "psn"})
+			}
+			fetcher := &fakeFetcher{polls: tt.polls}
+			var events []MatchEvent
+			w := New(Config{
+				Squads:         []Squad{squad},
+				Store:          matchesStore,
+				NotifyExisting: tt.notifyExisting,
+				Fetch:          fetcher.fetch,
+				Sinks: []Sink{SinkFunc(func(ctx context.Context, event MatchEvent) error {
+					events = append(events, event)
+					return nil
+				})},
+			})
+
+			for i, want := range tt.want {
+				fetcher.poll = i
+				events = nil
+				w.PollOnce(context.Background())
+				if got := eventsString(events); got != want {
+					t.Errorf("poll %d: expected events %q, got %q", i, want, got)
+				}
+			}
+		})
+	}
+}